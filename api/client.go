@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
 	v1alpha1 "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
@@ -40,6 +42,50 @@ type DatabaseFilter struct {
 	Engines           []v1pb.Engine
 	Labels            []*Label
 	ExcludeUnassigned bool
+	// Expression is the raw CEL filter joined with other conditions.
+	Expression string
+}
+
+// ChangelogFilter is the filter for list changelogs API.
+type ChangelogFilter struct {
+	Types        []v1pb.Changelog_Type
+	Statuses     []v1pb.Changelog_Status
+	CreateAfter  time.Time
+	CreateBefore time.Time
+	// FullStatement requests the full view with the complete statement, it downloads every statement.
+	FullStatement bool
+}
+
+// RevisionFilter is the filter for list revisions API.
+type RevisionFilter struct {
+	Release      string
+	ShowDeleted  bool
+	CreateAfter  time.Time
+	CreateBefore time.Time
+}
+
+// AuditLogFilter is the filter for search audit logs API.
+type AuditLogFilter struct {
+	Method       string
+	Resource     string
+	User         string
+	Severity     v1pb.AuditLog_Severity
+	CreateAfter  time.Time
+	CreateBefore time.Time
+	// Limit is the maximum count of audit logs to return, 0 means no limit.
+	Limit int
+}
+
+// IssueFilter is the filter for list issues API.
+type IssueFilter struct {
+	Status v1pb.IssueStatus
+	// Title is the exact issue title to match.
+	Title string
+}
+
+// GetCreateDatabaseIssueTitle returns the title of the issue to create the database.
+func GetCreateDatabaseIssueTitle(databaseName string) string {
+	return fmt.Sprintf("[Terraform] Create database %s", databaseName)
 }
 
 // UserFilter is the filter for list users API.
@@ -56,12 +102,22 @@ type GroupFilter struct {
 	Project string
 }
 
+// LabelConfig is the provider-level config for the instance, database and project labels.
+type LabelConfig struct {
+	// DefaultLabels are merged into the resource labels, the resource labels take precedence.
+	DefaultLabels map[string]string
+	// IgnoreLabelPrefixes are the label key prefixes excluded from the diff and preserved on update.
+	IgnoreLabelPrefixes []string
+}
+
 // Client is the API message for Bytebase OpenAPI client.
 type Client interface {
 	// GetWorkspaceName returns the workspace resource name in "workspaces/{workspace-id}" format.
 	GetWorkspaceName() string
 	// GetDefaultProjectName returns the workspace default project resource name.
 	GetDefaultProjectName() string
+	// GetLabelConfig returns the provider-level labels config.
+	GetLabelConfig() *LabelConfig
 
 	// Instance
 	// ListInstance will return instances.
@@ -70,14 +126,25 @@ type Client interface {
 	GetInstance(ctx context.Context, instanceName string) (*v1pb.Instance, error)
 	// CreateInstance creates the instance.
 	CreateInstance(ctx context.Context, instanceID string, instance *v1pb.Instance) (*v1pb.Instance, error)
+	// ValidateInstance tests the data source connections of the instance without creating it.
+	ValidateInstance(ctx context.Context, instanceID string, instance *v1pb.Instance) error
 	// UpdateInstance updates the instance.
 	UpdateInstance(ctx context.Context, patch *v1pb.Instance, updateMasks []string) (*v1pb.Instance, error)
 	// UndeleteInstance undeletes the instance.
 	UndeleteInstance(ctx context.Context, instanceName string) (*v1pb.Instance, error)
-	// DeleteInstance deletes the instance.
-	DeleteInstance(ctx context.Context, instanceName string) error
+	// DeleteInstance deletes the instance. The instance is permanently deleted if purge is true, otherwise it can be undeleted.
+	DeleteInstance(ctx context.Context, instanceName string, purge bool) error
 	// SyncInstanceSchema will trigger the schema sync for an instance.
 	SyncInstanceSchema(ctx context.Context, instanceName string) error
+	// AddDataSource adds the data source to the instance.
+	AddDataSource(ctx context.Context, instanceName string, dataSource *v1pb.DataSource) (*v1pb.Instance, error)
+	// UpdateDataSource updates the data source in the instance.
+	UpdateDataSource(ctx context.Context, instanceName string, dataSource *v1pb.DataSource, updateMasks []string) (*v1pb.Instance, error)
+	// ValidateDataSource tests the data source connection in the existing instance without changing it.
+	// The data source is validated as added if the update masks are empty, otherwise as updated with the update masks.
+	ValidateDataSource(ctx context.Context, instanceName string, dataSource *v1pb.DataSource, updateMasks []string) error
+	// RemoveDataSource removes the data source from the instance.
+	RemoveDataSource(ctx context.Context, instanceName string, dataSource *v1pb.DataSource) (*v1pb.Instance, error)
 
 	// Policy
 	// ListPolicies lists policies in a specific resource.
@@ -102,6 +169,28 @@ type Client interface {
 	GetDatabaseCatalog(ctx context.Context, databaseName string) (*v1pb.DatabaseCatalog, error)
 	// UpdateDatabaseCatalog patches the database catalog.
 	UpdateDatabaseCatalog(ctx context.Context, patch *v1pb.DatabaseCatalog) (*v1pb.DatabaseCatalog, error)
+	// CreateDatabase creates the database on the instance through the plan, issue and rollout, and returns the issue.
+	CreateDatabase(ctx context.Context, projectName string, config *v1pb.Plan_CreateDatabaseConfig) (*v1pb.Issue, error)
+	// DropDatabase runs the drop statement for the database through the plan, issue and rollout, and returns the issue.
+	DropDatabase(ctx context.Context, projectName, databaseName, statement string) (*v1pb.Issue, error)
+	// ChangeDatabase runs the statement in the database through the plan, issue and rollout, and returns the issue.
+	ChangeDatabase(ctx context.Context, projectName, databaseName, title, statement string) (*v1pb.Issue, error)
+	// GetRollout gets the rollout by name.
+	GetRollout(ctx context.Context, rolloutName string) (*v1pb.Rollout, error)
+	// GetIssue gets the issue by name.
+	GetIssue(ctx context.Context, issueName string) (*v1pb.Issue, error)
+	// ListIssues lists the issues in the project.
+	ListIssues(ctx context.Context, projectName string, filter *IssueFilter) ([]*v1pb.Issue, error)
+	// ListChangelogs lists the changelogs of the database.
+	ListChangelogs(ctx context.Context, databaseName string, filter *ChangelogFilter) ([]*v1pb.Changelog, error)
+	// ListRevisions lists the revisions of the database.
+	ListRevisions(ctx context.Context, databaseName string, filter *RevisionFilter) ([]*v1pb.Revision, error)
+	// GetDatabaseSchema gets the schema DDL of the database.
+	GetDatabaseSchema(ctx context.Context, databaseName string) (*v1pb.DatabaseSchema, error)
+	// DiffSchema returns the migration DDL from the database schema to the target schema.
+	DiffSchema(ctx context.Context, request *v1pb.DiffSchemaRequest) (*v1pb.DiffSchemaResponse, error)
+	// GetDatabaseMetadata gets the schemas, tables and columns metadata of the database.
+	GetDatabaseMetadata(ctx context.Context, databaseName string) (*v1pb.DatabaseMetadata, error)
 
 	// Project
 	// GetProject gets the project by project full name.
@@ -114,8 +203,8 @@ type Client interface {
 	UpdateProject(ctx context.Context, patch *v1pb.Project, updateMask []string) (*v1pb.Project, error)
 	// UndeleteProject undeletes the project.
 	UndeleteProject(ctx context.Context, projectName string) (*v1pb.Project, error)
-	// DeleteProject deletes the project.
-	DeleteProject(ctx context.Context, projectName string) error
+	// DeleteProject deletes the project. The project is permanently deleted if purge is true, otherwise it can be undeleted.
+	DeleteProject(ctx context.Context, projectName string, purge bool) error
 	// GetProjectIAMPolicy gets the project IAM policy by project full name.
 	GetProjectIAMPolicy(ctx context.Context, projectName string) (*v1pb.IamPolicy, error)
 	// SetProjectIAMPolicy sets the project IAM policy.
@@ -253,4 +342,14 @@ type Client interface {
 	UpdateIdentityProvider(ctx context.Context, patch *v1pb.IdentityProvider, updateMasks []string) (*v1pb.IdentityProvider, error)
 	// DeleteIdentityProvider deletes the identity provider.
 	DeleteIdentityProvider(ctx context.Context, name string) error
+
+	// SQL
+	// CheckSQL checks the SQL statement against the SQL review rules.
+	CheckSQL(ctx context.Context, request *v1pb.CheckRequest) (*v1pb.CheckResponse, error)
+	// QuerySQL runs the read-only query against the database.
+	QuerySQL(ctx context.Context, request *v1pb.QueryRequest) (*v1pb.QueryResponse, error)
+
+	// AuditLog
+	// SearchAuditLogs searches the audit logs in the parent, ordered by the create time descending.
+	SearchAuditLogs(ctx context.Context, parent string, filter *AuditLogFilter) ([]*v1pb.AuditLog, error)
 }
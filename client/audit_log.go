@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+)
+
+// buildAuditLogFilter builds the CEL filter for the search audit logs API, the user input values are quoted and escaped.
+func buildAuditLogFilter(filter *api.AuditLogFilter) string {
+	params := []string{}
+
+	if v := filter.Method; v != "" {
+		params = append(params, fmt.Sprintf(`method == %s`, strconv.Quote(v)))
+	}
+	if v := filter.Resource; v != "" {
+		params = append(params, fmt.Sprintf(`resource == %s`, strconv.Quote(v)))
+	}
+	if v := filter.User; v != "" {
+		params = append(params, fmt.Sprintf(`user == %s`, strconv.Quote(v)))
+	}
+	if v := filter.Severity; v != v1pb.AuditLog_SEVERITY_UNSPECIFIED {
+		params = append(params, fmt.Sprintf(`severity == "%s"`, v.String()))
+	}
+	if v := filter.CreateAfter; !v.IsZero() {
+		params = append(params, fmt.Sprintf(`create_time >= "%s"`, v.UTC().Format(time.RFC3339)))
+	}
+	if v := filter.CreateBefore; !v.IsZero() {
+		params = append(params, fmt.Sprintf(`create_time <= "%s"`, v.UTC().Format(time.RFC3339)))
+	}
+
+	return strings.Join(params, " && ")
+}
+
+// SearchAuditLogs searches the audit logs in the parent using Connect RPC.
+func (c *client) SearchAuditLogs(ctx context.Context, parent string, filter *api.AuditLogFilter) ([]*v1pb.AuditLog, error) {
+	if c.auditLogClient == nil {
+		return nil, errors.New("audit log service client not initialized")
+	}
+
+	res := []*v1pb.AuditLog{}
+	pageToken := ""
+	startTime := time.Now()
+	filterStr := buildAuditLogFilter(filter)
+	pageSize := int32(500)
+	if filter.Limit > 0 && filter.Limit < int(pageSize) {
+		pageSize = int32(filter.Limit)
+	}
+
+	for {
+		startTimePerPage := time.Now()
+
+		req := connect.NewRequest(&v1pb.SearchAuditLogsRequest{
+			Parent:    parent,
+			Filter:    filterStr,
+			OrderBy:   "create_time desc",
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		})
+
+		resp, err := c.auditLogClient.SearchAuditLogs(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, resp.Msg.AuditLogs...)
+
+		tflog.Debug(ctx, "[search audit log per page]", map[string]interface{}{
+			"count": len(resp.Msg.AuditLogs),
+			"ms":    time.Since(startTimePerPage).Milliseconds(),
+		})
+
+		if filter.Limit > 0 && len(res) >= filter.Limit {
+			res = res[:filter.Limit]
+			break
+		}
+
+		pageToken = resp.Msg.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	tflog.Debug(ctx, "[search audit log]", map[string]interface{}{
+		"total": len(res),
+		"ms":    time.Since(startTime).Milliseconds(),
+	})
+
+	return res, nil
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+)
+
+func TestBuildAuditLogFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *api.AuditLogFilter
+		want   string
+	}{
+		{
+			name:   "empty",
+			filter: &api.AuditLogFilter{},
+			want:   "",
+		},
+		{
+			name: "all fields",
+			filter: &api.AuditLogFilter{
+				Method:       "/bytebase.v1.ProjectService/SetIamPolicy",
+				Resource:     "projects/sample",
+				User:         "users/dev@bytebase.com",
+				Severity:     v1pb.AuditLog_ERROR,
+				CreateAfter:  time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+				CreateBefore: time.Date(2025, time.January, 2, 8, 0, 0, 0, time.FixedZone("UTC+8", 8*60*60)),
+			},
+			want: `method == "/bytebase.v1.ProjectService/SetIamPolicy" && resource == "projects/sample" && user == "users/dev@bytebase.com" && severity == "ERROR" && create_time >= "2025-01-01T00:00:00Z" && create_time <= "2025-01-02T00:00:00Z"`,
+		},
+		{
+			name: "escape values",
+			filter: &api.AuditLogFilter{
+				Resource: `projects/a" || true || "`,
+				User:     `users/a\b`,
+			},
+			want: `resource == "projects/a\" || true || \"" && user == "users/a\\b"`,
+		},
+	}
+	for _, test := range tests {
+		if got := buildAuditLogFilter(test.filter); got != test.want {
+			t.Errorf("%s: buildAuditLogFilter() = %s, want %s", test.name, got, test.want)
+		}
+	}
+}
@@ -21,6 +21,7 @@ type client struct {
 	url                string
 	workspaceName      string
 	defaultProjectName string
+	labelConfig        *api.LabelConfig
 	client             *http.Client
 
 	// Connect RPC clients
@@ -43,6 +44,13 @@ type client struct {
 	workloadIdentityClient bytebasev1connect.WorkloadIdentityServiceClient
 	subscriptionClient     bytebasev1connect.SubscriptionServiceClient
 	idpClient              bytebasev1connect.IdentityProviderServiceClient
+	revisionClient         bytebasev1connect.RevisionServiceClient
+	sqlClient              bytebasev1connect.SQLServiceClient
+	auditLogClient         bytebasev1connect.AuditLogServiceClient
+	sheetClient            bytebasev1connect.SheetServiceClient
+	planClient             bytebasev1connect.PlanServiceClient
+	issueClient            bytebasev1connect.IssueServiceClient
+	rolloutClient          bytebasev1connect.RolloutServiceClient
 }
 
 // GetWorkspaceName returns the workspace resource name.
@@ -55,8 +63,14 @@ func (c *client) GetDefaultProjectName() string {
 	return c.defaultProjectName
 }
 
+// GetLabelConfig returns the provider-level labels config.
+func (c *client) GetLabelConfig() *api.LabelConfig {
+	return c.labelConfig
+}
+
 type options struct {
 	customHeaders map[string]string
+	labelConfig   *api.LabelConfig
 }
 
 // Option configures the Bytebase API client.
@@ -69,6 +83,13 @@ func WithCustomHeaders(headers map[string]string) Option {
 	}
 }
 
+// WithLabelConfig configures the provider-level labels config.
+func WithLabelConfig(config *api.LabelConfig) Option {
+	return func(o *options) {
+		o.labelConfig = config
+	}
+}
+
 func copyHeaders(headers map[string]string) map[string]string {
 	if len(headers) == 0 {
 		return nil
@@ -82,13 +103,16 @@ func copyHeaders(headers map[string]string) map[string]string {
 
 // NewClient returns the new Bytebase API client.
 func NewClient(url, email, password string, opts ...Option) (api.Client, error) {
-	clientOptions := &options{}
+	clientOptions := &options{
+		labelConfig: &api.LabelConfig{},
+	}
 	for _, opt := range opts {
 		opt(clientOptions)
 	}
 
 	c := client{
-		url: strings.TrimSuffix(url, "/"),
+		url:         strings.TrimSuffix(url, "/"),
+		labelConfig: clientOptions.labelConfig,
 	}
 
 	// Use standard HTTP client that supports both HTTP/1.1 and HTTP/2
@@ -138,6 +162,13 @@ func NewClient(url, email, password string, opts ...Option) (api.Client, error)
 	c.workloadIdentityClient = bytebasev1connect.NewWorkloadIdentityServiceClient(c.client, c.url, interceptors)
 	c.subscriptionClient = bytebasev1connect.NewSubscriptionServiceClient(c.client, c.url, interceptors)
 	c.idpClient = bytebasev1connect.NewIdentityProviderServiceClient(c.client, c.url, interceptors)
+	c.revisionClient = bytebasev1connect.NewRevisionServiceClient(c.client, c.url, interceptors)
+	c.sqlClient = bytebasev1connect.NewSQLServiceClient(c.client, c.url, interceptors)
+	c.auditLogClient = bytebasev1connect.NewAuditLogServiceClient(c.client, c.url, interceptors)
+	c.sheetClient = bytebasev1connect.NewSheetServiceClient(c.client, c.url, interceptors)
+	c.planClient = bytebasev1connect.NewPlanServiceClient(c.client, c.url, interceptors)
+	c.issueClient = bytebasev1connect.NewIssueServiceClient(c.client, c.url, interceptors)
+	c.rolloutClient = bytebasev1connect.NewRolloutServiceClient(c.client, c.url, interceptors)
 
 	// Fetch workspace ID from actuator
 	actuatorResp, err := c.actuatorClient.GetActuatorInfo(context.Background(), connect.NewRequest(&v1pb.GetActuatorInfoRequest{}))
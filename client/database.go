@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"connectrpc.com/connect"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/bytebase/terraform-provider-bytebase/api"
 )
@@ -70,6 +72,9 @@ func buildDatabaseFilter(filter *api.DatabaseFilter) string {
 			params = append(params, fmt.Sprintf(`label == "%s:%s"`, key, strings.Join(values, ",")))
 		}
 	}
+	if v := filter.Expression; v != "" {
+		params = append(params, fmt.Sprintf("(%s)", v))
+	}
 
 	return strings.Join(params, " && ")
 }
@@ -191,3 +196,135 @@ func (c *client) UpdateDatabaseCatalog(ctx context.Context, patch *v1pb.Database
 
 	return resp.Msg, nil
 }
+
+// ListChangelogs lists the changelogs of the database using Connect RPC.
+func (c *client) ListChangelogs(ctx context.Context, databaseName string, filter *api.ChangelogFilter) ([]*v1pb.Changelog, error) {
+	if c.databaseClient == nil {
+		return nil, errors.New("database service client not initialized")
+	}
+
+	res := []*v1pb.Changelog{}
+	pageToken := ""
+	startTime := time.Now()
+	view := v1pb.ChangelogView_CHANGELOG_VIEW_BASIC
+	if filter.FullStatement {
+		view = v1pb.ChangelogView_CHANGELOG_VIEW_FULL
+	}
+
+	for {
+		startTimePerPage := time.Now()
+
+		req := connect.NewRequest(&v1pb.ListChangelogsRequest{
+			Parent:    databaseName,
+			View:      view,
+			PageSize:  500,
+			PageToken: pageToken,
+		})
+
+		resp, err := c.databaseClient.ListChangelogs(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		// The server filter only understands a subset of the fields, so the filter is applied on the client side.
+		for _, changelog := range resp.Msg.Changelogs {
+			if len(filter.Types) > 0 && !slices.Contains(filter.Types, changelog.GetType()) {
+				continue
+			}
+			if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, changelog.GetStatus()) {
+				continue
+			}
+			if !inTimeRange(changelog.GetCreateTime(), filter.CreateAfter, filter.CreateBefore) {
+				continue
+			}
+			res = append(res, changelog)
+		}
+
+		tflog.Debug(ctx, "[list changelog per page]", map[string]interface{}{
+			"count": len(resp.Msg.Changelogs),
+			"ms":    time.Since(startTimePerPage).Milliseconds(),
+		})
+
+		pageToken = resp.Msg.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	tflog.Debug(ctx, "[list changelog]", map[string]interface{}{
+		"total": len(res),
+		"ms":    time.Since(startTime).Milliseconds(),
+	})
+
+	return res, nil
+}
+
+// GetDatabaseSchema gets the schema DDL of the database using Connect RPC.
+func (c *client) GetDatabaseSchema(ctx context.Context, databaseName string) (*v1pb.DatabaseSchema, error) {
+	if c.databaseClient == nil {
+		return nil, errors.New("database service client not initialized")
+	}
+
+	req := connect.NewRequest(&v1pb.GetDatabaseSchemaRequest{
+		Name: fmt.Sprintf("%s/schema", databaseName),
+	})
+
+	resp, err := c.databaseClient.GetDatabaseSchema(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Msg, nil
+}
+
+// GetDatabaseMetadata gets the schemas, tables and columns metadata of the database using Connect RPC.
+func (c *client) GetDatabaseMetadata(ctx context.Context, databaseName string) (*v1pb.DatabaseMetadata, error) {
+	if c.databaseClient == nil {
+		return nil, errors.New("database service client not initialized")
+	}
+
+	req := connect.NewRequest(&v1pb.GetDatabaseMetadataRequest{
+		Name: fmt.Sprintf("%s/metadata", databaseName),
+	})
+
+	resp, err := c.databaseClient.GetDatabaseMetadata(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Msg, nil
+}
+
+// DiffSchema returns the migration DDL from the database schema to the target schema using Connect RPC.
+func (c *client) DiffSchema(ctx context.Context, request *v1pb.DiffSchemaRequest) (*v1pb.DiffSchemaResponse, error) {
+	if c.databaseClient == nil {
+		return nil, errors.New("database service client not initialized")
+	}
+
+	req := connect.NewRequest(request)
+
+	resp, err := c.databaseClient.DiffSchema(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Msg, nil
+}
+
+// inTimeRange checks if the timestamp is in the [after, before] range, the zero time means no bound.
+func inTimeRange(ts *timestamppb.Timestamp, after, before time.Time) bool {
+	if after.IsZero() && before.IsZero() {
+		return true
+	}
+	if ts == nil {
+		return false
+	}
+	t := ts.AsTime()
+	if !after.IsZero() && t.Before(after) {
+		return false
+	}
+	if !before.IsZero() && t.After(before) {
+		return false
+	}
+	return true
+}
@@ -132,6 +132,22 @@ func (c *client) CreateInstance(ctx context.Context, instanceID string, instance
 	return resp.Msg, nil
 }
 
+// ValidateInstance tests the data source connections of the instance without creating it using Connect RPC.
+func (c *client) ValidateInstance(ctx context.Context, instanceID string, instance *v1pb.Instance) error {
+	if c.instanceClient == nil {
+		return errors.New("instance service client not initialized")
+	}
+
+	req := connect.NewRequest(&v1pb.CreateInstanceRequest{
+		InstanceId:   instanceID,
+		Instance:     instance,
+		ValidateOnly: true,
+	})
+
+	_, err := c.instanceClient.CreateInstance(ctx, req)
+	return err
+}
+
 // UpdateInstance updates the instance using Connect RPC.
 func (c *client) UpdateInstance(ctx context.Context, patch *v1pb.Instance, updateMasks []string) (*v1pb.Instance, error) {
 	if c.instanceClient == nil {
@@ -183,14 +199,97 @@ func (c *client) SyncInstanceSchema(ctx context.Context, instanceName string) er
 	return err
 }
 
+// AddDataSource adds the data source to the instance using Connect RPC.
+func (c *client) AddDataSource(ctx context.Context, instanceName string, dataSource *v1pb.DataSource) (*v1pb.Instance, error) {
+	if c.instanceClient == nil {
+		return nil, errors.New("instance service client not initialized")
+	}
+
+	req := connect.NewRequest(&v1pb.AddDataSourceRequest{
+		Name:       instanceName,
+		DataSource: dataSource,
+	})
+
+	resp, err := c.instanceClient.AddDataSource(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Msg, nil
+}
+
+// UpdateDataSource updates the data source in the instance using Connect RPC.
+func (c *client) UpdateDataSource(ctx context.Context, instanceName string, dataSource *v1pb.DataSource, updateMasks []string) (*v1pb.Instance, error) {
+	if c.instanceClient == nil {
+		return nil, errors.New("instance service client not initialized")
+	}
+
+	req := connect.NewRequest(&v1pb.UpdateDataSourceRequest{
+		Name:       instanceName,
+		DataSource: dataSource,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: updateMasks},
+	})
+
+	resp, err := c.instanceClient.UpdateDataSource(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Msg, nil
+}
+
+// ValidateDataSource tests the data source connection in the existing instance without changing it using Connect RPC.
+func (c *client) ValidateDataSource(ctx context.Context, instanceName string, dataSource *v1pb.DataSource, updateMasks []string) error {
+	if c.instanceClient == nil {
+		return errors.New("instance service client not initialized")
+	}
+
+	if len(updateMasks) == 0 {
+		_, err := c.instanceClient.AddDataSource(ctx, connect.NewRequest(&v1pb.AddDataSourceRequest{
+			Name:         instanceName,
+			DataSource:   dataSource,
+			ValidateOnly: true,
+		}))
+		return err
+	}
+
+	_, err := c.instanceClient.UpdateDataSource(ctx, connect.NewRequest(&v1pb.UpdateDataSourceRequest{
+		Name:         instanceName,
+		DataSource:   dataSource,
+		UpdateMask:   &fieldmaskpb.FieldMask{Paths: updateMasks},
+		ValidateOnly: true,
+	}))
+	return err
+}
+
+// RemoveDataSource removes the data source from the instance using Connect RPC.
+func (c *client) RemoveDataSource(ctx context.Context, instanceName string, dataSource *v1pb.DataSource) (*v1pb.Instance, error) {
+	if c.instanceClient == nil {
+		return nil, errors.New("instance service client not initialized")
+	}
+
+	req := connect.NewRequest(&v1pb.RemoveDataSourceRequest{
+		Name:       instanceName,
+		DataSource: dataSource,
+	})
+
+	resp, err := c.instanceClient.RemoveDataSource(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Msg, nil
+}
+
 // DeleteInstance deletes the instance.
-func (c *client) DeleteInstance(ctx context.Context, name string) error {
+func (c *client) DeleteInstance(ctx context.Context, name string, purge bool) error {
 	if c.instanceClient == nil {
 		return errors.New("instance service client not initialized")
 	}
 
 	req := connect.NewRequest(&v1pb.DeleteInstanceRequest{
-		Name: name,
+		Name:  name,
+		Purge: purge,
 	})
 
 	_, err := c.instanceClient.DeleteInstance(ctx, req)
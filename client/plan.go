@@ -0,0 +1,203 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+)
+
+// CreateDatabase creates the database on the instance through the plan, issue and rollout using Connect RPC.
+func (c *client) CreateDatabase(ctx context.Context, projectName string, config *v1pb.Plan_CreateDatabaseConfig) (*v1pb.Issue, error) {
+	title := api.GetCreateDatabaseIssueTitle(fmt.Sprintf("%s/databases/%s", config.Target, config.Database))
+	return c.createIssueWithPlan(ctx, projectName, title, &v1pb.Plan_Spec{
+		Id: "create-database",
+		Config: &v1pb.Plan_Spec_CreateDatabaseConfig{
+			CreateDatabaseConfig: config,
+		},
+	})
+}
+
+// DropDatabase runs the drop statement for the database through the plan, issue and rollout using Connect RPC.
+func (c *client) DropDatabase(ctx context.Context, projectName, databaseName, statement string) (*v1pb.Issue, error) {
+	return c.ChangeDatabase(ctx, projectName, databaseName, fmt.Sprintf("[Terraform] Drop database %s", databaseName), statement)
+}
+
+// ChangeDatabase runs the statement in the database through the plan, issue and rollout using Connect RPC.
+func (c *client) ChangeDatabase(ctx context.Context, projectName, databaseName, title, statement string) (*v1pb.Issue, error) {
+	if c.sheetClient == nil {
+		return nil, errors.New("sheet service client not initialized")
+	}
+
+	sheetResp, err := c.sheetClient.CreateSheet(ctx, connect.NewRequest(&v1pb.CreateSheetRequest{
+		Parent: projectName,
+		Sheet: &v1pb.Sheet{
+			Content: []byte(statement),
+		},
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return c.createIssueWithPlan(ctx, projectName, title, &v1pb.Plan_Spec{
+		Id: "change-database",
+		Config: &v1pb.Plan_Spec_ChangeDatabaseConfig{
+			ChangeDatabaseConfig: &v1pb.Plan_ChangeDatabaseConfig{
+				Targets: []string{databaseName},
+				Sheet:   sheetResp.Msg.Name,
+			},
+		},
+	})
+}
+
+// createIssueWithPlan creates the plan with the spec, then creates the issue and the rollout for the plan, and runs the rollout tasks.
+// The tasks cannot run before the issue is approved if the project requires the approval.
+// The returned issue has the rollout name, use GetRollout to check the task status.
+func (c *client) createIssueWithPlan(ctx context.Context, projectName, title string, spec *v1pb.Plan_Spec) (*v1pb.Issue, error) {
+	if c.planClient == nil {
+		return nil, errors.New("plan service client not initialized")
+	}
+	if c.issueClient == nil {
+		return nil, errors.New("issue service client not initialized")
+	}
+	if c.rolloutClient == nil {
+		return nil, errors.New("rollout service client not initialized")
+	}
+
+	planResp, err := c.planClient.CreatePlan(ctx, connect.NewRequest(&v1pb.CreatePlanRequest{
+		Parent: projectName,
+		Plan: &v1pb.Plan{
+			Title: title,
+			Specs: []*v1pb.Plan_Spec{spec},
+		},
+	}))
+	if err != nil {
+		return nil, err
+	}
+	plan := planResp.Msg
+
+	issueResp, err := c.issueClient.CreateIssue(ctx, connect.NewRequest(&v1pb.CreateIssueRequest{
+		Parent: projectName,
+		Issue: &v1pb.Issue{
+			Title: title,
+			Type:  v1pb.Issue_DATABASE_CHANGE,
+			Plan:  plan.Name,
+		},
+	}))
+	if err != nil {
+		return nil, err
+	}
+	issue := issueResp.Msg
+
+	rolloutResp, err := c.rolloutClient.CreateRollout(ctx, connect.NewRequest(&v1pb.CreateRolloutRequest{
+		Parent: projectName,
+		Rollout: &v1pb.Rollout{
+			Plan: plan.Name,
+		},
+	}))
+	if err != nil {
+		return nil, err
+	}
+	rollout := rolloutResp.Msg
+	issue.Rollout = rollout.Name
+
+	for _, stage := range rollout.Stages {
+		tasks := []string{}
+		for _, task := range stage.Tasks {
+			tasks = append(tasks, task.Name)
+		}
+		if len(tasks) == 0 {
+			continue
+		}
+		if _, err := c.rolloutClient.BatchRunTasks(ctx, connect.NewRequest(&v1pb.BatchRunTasksRequest{
+			Parent: stage.Name,
+			Tasks:  tasks,
+		})); err != nil {
+			// The tasks cannot run before the issue is approved, they're left to run after the approval.
+			tflog.Warn(ctx, fmt.Sprintf("failed to run the tasks in stage %s, the issue %s may require approval: %v", stage.Name, issue.Name, err))
+			break
+		}
+	}
+
+	tflog.Debug(ctx, "[create issue with plan]", map[string]interface{}{
+		"plan":    plan.Name,
+		"issue":   issue.Name,
+		"rollout": rollout.Name,
+	})
+
+	return issue, nil
+}
+
+// GetRollout gets the rollout by name using Connect RPC.
+func (c *client) GetRollout(ctx context.Context, rolloutName string) (*v1pb.Rollout, error) {
+	if c.rolloutClient == nil {
+		return nil, errors.New("rollout service client not initialized")
+	}
+
+	resp, err := c.rolloutClient.GetRollout(ctx, connect.NewRequest(&v1pb.GetRolloutRequest{
+		Name: rolloutName,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// GetIssue gets the issue by name using Connect RPC.
+func (c *client) GetIssue(ctx context.Context, issueName string) (*v1pb.Issue, error) {
+	if c.issueClient == nil {
+		return nil, errors.New("issue service client not initialized")
+	}
+
+	resp, err := c.issueClient.GetIssue(ctx, connect.NewRequest(&v1pb.GetIssueRequest{
+		Name: issueName,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// ListIssues lists the issues in the project using Connect RPC.
+func (c *client) ListIssues(ctx context.Context, projectName string, filter *api.IssueFilter) ([]*v1pb.Issue, error) {
+	if c.issueClient == nil {
+		return nil, errors.New("issue service client not initialized")
+	}
+
+	params := []string{}
+	if v := filter.Status; v != v1pb.IssueStatus_ISSUE_STATUS_UNSPECIFIED {
+		params = append(params, fmt.Sprintf(`status == "%s"`, v.String()))
+	}
+
+	res := []*v1pb.Issue{}
+	pageToken := ""
+	for {
+		resp, err := c.issueClient.ListIssues(ctx, connect.NewRequest(&v1pb.ListIssuesRequest{
+			Parent:    projectName,
+			Filter:    strings.Join(params, " && "),
+			PageSize:  500,
+			PageToken: pageToken,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range resp.Msg.Issues {
+			// The server filter doesn't support the exact title match, so the title is matched on the client side.
+			if filter.Title != "" && issue.Title != filter.Title {
+				continue
+			}
+			res = append(res, issue)
+		}
+		pageToken = resp.Msg.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+	return res, nil
+}
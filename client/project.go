@@ -260,13 +260,14 @@ func (c *client) UndeleteProject(ctx context.Context, projectName string) (*v1pb
 }
 
 // DeleteProject deletes the project.
-func (c *client) DeleteProject(ctx context.Context, name string) error {
+func (c *client) DeleteProject(ctx context.Context, name string, purge bool) error {
 	if c.projectClient == nil {
 		return errors.New("project service client not initialized")
 	}
 
 	req := connect.NewRequest(&v1pb.DeleteProjectRequest{
-		Name: name,
+		Name:  name,
+		Purge: purge,
 	})
 
 	_, err := c.projectClient.DeleteProject(ctx, req)
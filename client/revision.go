@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+)
+
+// ListRevisions lists the revisions of the database using Connect RPC.
+func (c *client) ListRevisions(ctx context.Context, databaseName string, filter *api.RevisionFilter) ([]*v1pb.Revision, error) {
+	if c.revisionClient == nil {
+		return nil, errors.New("revision service client not initialized")
+	}
+
+	res := []*v1pb.Revision{}
+	pageToken := ""
+	startTime := time.Now()
+
+	for {
+		req := connect.NewRequest(&v1pb.ListRevisionsRequest{
+			Parent:      databaseName,
+			ShowDeleted: filter.ShowDeleted,
+			PageSize:    500,
+			PageToken:   pageToken,
+		})
+
+		resp, err := c.revisionClient.ListRevisions(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, revision := range resp.Msg.Revisions {
+			if filter.Release != "" && revision.GetRelease() != filter.Release {
+				continue
+			}
+			if !inTimeRange(revision.GetCreateTime(), filter.CreateAfter, filter.CreateBefore) {
+				continue
+			}
+			res = append(res, revision)
+		}
+
+		pageToken = resp.Msg.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	tflog.Debug(ctx, "[list revision]", map[string]interface{}{
+		"total": len(res),
+		"ms":    time.Since(startTime).Milliseconds(),
+	})
+
+	return res, nil
+}
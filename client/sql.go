@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+	"connectrpc.com/connect"
+)
+
+// CheckSQL checks the SQL statement against the SQL review rules using Connect RPC.
+func (c *client) CheckSQL(ctx context.Context, request *v1pb.CheckRequest) (*v1pb.CheckResponse, error) {
+	if c.sqlClient == nil {
+		return nil, errors.New("sql service client not initialized")
+	}
+
+	req := connect.NewRequest(request)
+
+	resp, err := c.sqlClient.Check(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Msg, nil
+}
+
+// QuerySQL runs the read-only query against the database using Connect RPC.
+func (c *client) QuerySQL(ctx context.Context, request *v1pb.QueryRequest) (*v1pb.QueryResponse, error) {
+	if c.sqlClient == nil {
+		return nil, errors.New("sql service client not initialized")
+	}
+
+	req := connect.NewRequest(request)
+
+	resp, err := c.sqlClient.Query(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Msg, nil
+}
@@ -29,6 +29,35 @@ output "all_databases" {
   value = data.bytebase_database_list.all
 }
 
+# Only fetch the project and the users table catalog for a large fleet.
+data "bytebase_database_list" "users_catalog" {
+  environment         = "environments/prod"
+  fields              = ["project"]
+  include_catalog     = true
+  catalog_parallelism = 20
+  catalog_tables      = ["users"]
+}
+
+data "bytebase_database_changelog_list" "recent_migrations" {
+  database          = "instances/test-sample-instance/databases/employee"
+  types             = ["MIGRATE"]
+  statuses          = ["DONE"]
+  create_time_after = "2025-01-01T00:00:00Z"
+}
+
+output "recent_migrations" {
+  value = data.bytebase_database_changelog_list.recent_migrations.changelogs
+}
+
+data "bytebase_database_revision_list" "release_revisions" {
+  database = "instances/test-sample-instance/databases/employee"
+  release  = "projects/sample-project/releases/v1"
+}
+
+output "release_revisions" {
+  value = data.bytebase_database_revision_list.release_revisions.revisions
+}
+
 # Example: OpenSearch / document-DB nested masking via object_schema_json.
 # The JSON must match the v1.ObjectSchema proto shape.
 # Replace <uuid-from-ui> with real semantic type IDs from the Bytebase
@@ -64,3 +93,143 @@ output "all_databases" {
 #     }
 #   }
 # }
+
+# Fail the plan when the prod schema drifts from staging.
+data "bytebase_schema_diff" "staging_to_prod" {
+  source_database = "instances/staging-instance/databases/employee"
+  target_database = "instances/prod-instance/databases/employee"
+
+  lifecycle {
+    postcondition {
+      condition     = self.in_sync
+      error_message = "Schema drift between staging and prod:\n${self.diff}"
+    }
+  }
+}
+
+# Drive the database group condition from the tenant reference data.
+data "bytebase_sql_query" "tenants" {
+  database  = "instances/prod-instance/databases/meta"
+  statement = "SELECT name FROM tenant WHERE active"
+  limit     = 500
+
+  timeouts {
+    read = "30s"
+  }
+}
+
+output "tenant_names" {
+  value = [for row in data.bytebase_sql_query.tenants.rows : row.name]
+}
+
+# Create the physical database through the Bytebase issue if it doesn't exist.
+resource "bytebase_database" "tenant" {
+  name              = "instances/test-sample-instance/databases/tenant_001"
+  project           = "projects/sample-project"
+  create_if_missing = true
+
+  create_options {
+    character_set = "utf8mb4"
+    collation     = "utf8mb4_general_ci"
+  }
+
+  # Drop the database on destroy, the confirmation must equal to the name.
+  drop_on_destroy   = true
+  drop_confirmation = "instances/test-sample-instance/databases/tenant_001"
+
+  timeouts {
+    create = "15m"
+  }
+}
+
+# Assign all tenant databases on the instance to the project in batches.
+resource "bytebase_project_databases" "tenants" {
+  project    = "projects/sample-project"
+  batch_size = 200
+
+  selector {
+    instances = ["instances/test-sample-instance"]
+    filter    = "name.matches(\"tenant_\")"
+  }
+}
+
+output "tenant_databases" {
+  value = bytebase_project_databases.tenants.resolved_databases
+}
+
+# Adopt the tenant databases created every day into the project, new tenants show in the next plan.
+resource "bytebase_database_adoption" "tenants" {
+  project     = "projects/sample-project"
+  instances   = ["instances/test-sample-instance", "instances/prod-sample-instance"]
+  condition   = "resource.database_name.startsWith(\"tenant_\")"
+  environment = "environments/prod"
+
+  labels = {
+    tier = "tenant"
+  }
+}
+
+# Only patch the declared columns, the classifications added in the UI are kept.
+resource "bytebase_database" "employee" {
+  name         = "instances/test-sample-instance/databases/employee"
+  project      = "projects/sample-project"
+  catalog_mode = "merge"
+
+  catalog {
+    schemas {
+      name = "public"
+      tables {
+        name = "salary"
+        columns {
+          name          = "amount"
+          semantic_type = "default"
+        }
+      }
+    }
+  }
+}
+
+# Own the catalog for a single column.
+resource "bytebase_database_column_catalog" "employee_email" {
+  database       = "instances/test-sample-instance/databases/employee"
+  schema         = "public"
+  table          = "employee"
+  column         = "email"
+  semantic_type  = "default"
+  classification = "1-1"
+}
+
+# Tag the PII columns in all production databases by the column name.
+resource "bytebase_classification_rules" "pii" {
+  selector {
+    environment = "environments/prod"
+    filter      = "engine == \"POSTGRES\""
+  }
+
+  rules {
+    column_pattern = "(?i)email|phone"
+    semantic_type  = "default"
+    classification = "1-1"
+  }
+
+  rules {
+    column_pattern = "(?i)^(ssn|passport_no)$"
+    table_pattern  = "(?i)customer"
+    classification = "1-2"
+  }
+}
+
+# Manage a single label, the labels written by other tools are kept.
+resource "bytebase_database_label" "employee_team" {
+  database = "instances/test-sample-instance/databases/employee"
+  key      = "team"
+  value    = "hr"
+}
+
+resource "bytebase_database_label" "employee_tags" {
+  database = "instances/test-sample-instance/databases/employee"
+  labels = {
+    tier   = "gold"
+    region = "us-east-1"
+  }
+}
@@ -33,3 +33,19 @@ data "bytebase_iam_policy" "project_iam" {
 output "project_iam" {
   value = data.bytebase_iam_policy.project_iam
 }
+
+# Detect the IAM changes made outside Terraform in the last 24 hours.
+data "bytebase_audit_log_list" "iam_changes" {
+  parent            = "projects/project-sample"
+  method            = "/bytebase.v1.ProjectService/SetIamPolicy"
+  create_time_after = timeadd(plantimestamp(), "-24h")
+}
+
+check "no_manual_iam_changes" {
+  assert {
+    condition = alltrue([
+      for log in data.bytebase_audit_log_list.iam_changes.audit_logs : log.user == "users/terraform@service.bytebase.com"
+    ])
+    error_message = "Found IAM changes made outside Terraform in the last 24 hours."
+  }
+}
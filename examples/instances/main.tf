@@ -29,6 +29,8 @@ resource "bytebase_instance" "mysql_password" {
   title       = "MySQL with Password Auth"
   engine      = "MYSQL"
   activation  = true
+  # Test the data source connections during plan.
+  validate_connection = true
 
   labels = {
     environment = "test"
@@ -741,6 +743,162 @@ resource "bytebase_instance" "cassandra" {
   }
 }
 
+###############################################################################
+# Example 25: Wait for the initial sync
+# Wait until the instance databases are synced, then manage the discovered databases in the same apply.
+###############################################################################
+resource "bytebase_instance" "postgres_wait_for_sync" {
+  resource_id   = "postgres-wait-for-sync-example"
+  environment   = "environments/test"
+  title         = "PostgreSQL waiting for sync"
+  engine        = "POSTGRES"
+  activation    = true
+  wait_for_sync = true
+
+  timeouts {
+    create = "5m"
+  }
+
+  data_sources {
+    id       = "admin"
+    type     = "ADMIN"
+    host     = "postgres.example.com"
+    port     = "5432"
+    username = "postgres"
+    password = "your-password"
+  }
+}
+
+# The databases are unknown until the instance is created, so apply the instance first with
+# -target=bytebase_instance.postgres_wait_for_sync when creating both in a new workspace.
+resource "bytebase_database" "postgres_wait_for_sync" {
+  for_each = bytebase_instance.postgres_wait_for_sync.databases
+
+  name        = each.value
+  project     = "projects/sample-project"
+  environment = "environments/test"
+}
+
+###############################################################################
+# Example 26: Write-only credentials
+# The password and SSH private key are never saved in the state.
+# Bump the *_version to rotate the credentials.
+###############################################################################
+variable "mysql_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+variable "mysql_ssh_private_key" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "bytebase_instance" "mysql_write_only" {
+  resource_id = "mysql-write-only-example"
+  environment = "environments/test"
+  title       = "MySQL with write-only credentials"
+  engine      = "MYSQL"
+  activation  = true
+
+  data_sources {
+    id       = "admin"
+    type     = "ADMIN"
+    host     = "mysql.example.com"
+    port     = "3306"
+    username = "admin"
+    ssh_host = "bastion.example.com"
+    ssh_port = "22"
+    ssh_user = "ubuntu"
+  }
+
+  data_source_credentials {
+    id                         = "admin"
+    password_wo                = var.mysql_password
+    password_wo_version        = 1
+    ssh_private_key_wo         = var.mysql_ssh_private_key
+    ssh_private_key_wo_version = 1
+  }
+}
+
+###############################################################################
+# Example 27: Read-only data source managed separately
+# The bytebase_instance keeps the READ_ONLY data sources not in its data_sources.
+###############################################################################
+
+resource "bytebase_instance_data_source" "mysql_replica" {
+  instance            = bytebase_instance.mysql_write_only.name
+  data_source_id      = "read-replica"
+  host                = "mysql-replica.example.com"
+  port                = "3306"
+  username            = "reader"
+  password_wo         = var.mysql_password
+  password_wo_version = 1
+}
+
+###############################################################################
+# Example 28: PostgreSQL role and MySQL user
+# The role statements run through the Bytebase issue in the project.
+###############################################################################
+variable "postgres_reader_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "bytebase_instance_role" "postgres_reader" {
+  instance            = bytebase_instance.postgres_wait_for_sync.name
+  role_name           = "reader"
+  project             = "projects/sample-project"
+  database            = "postgres"
+  password_wo         = var.postgres_reader_password
+  password_wo_version = 1
+  connection_limit    = 10
+  valid_until         = "2030-01-01 00:00:00+00"
+  attributes          = ["LOGIN"]
+}
+
+variable "mysql_reader_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+# The MySQL user is created with the caching_sha2_password hash, the database is required to run the statements.
+resource "bytebase_instance_role" "mysql_reader" {
+  instance            = bytebase_instance.mysql_write_only.name
+  role_name           = "reader"
+  host                = "10.0.0.%"
+  project             = "projects/sample-project"
+  database            = "app"
+  password_wo         = var.mysql_reader_password
+  password_wo_version = 1
+}
+
+###############################################################################
+# Example 29: Ephemeral preview instance
+# Purge the instance on destroy, so the next preview can reuse the resource_id.
+# The deletion protection is enabled by default, disable it for the preview.
+###############################################################################
+resource "bytebase_instance" "postgres_preview" {
+  resource_id         = "postgres-preview-example"
+  environment         = "environments/test"
+  title               = "PostgreSQL preview"
+  engine              = "POSTGRES"
+  purge_on_destroy    = true
+  deletion_protection = false
+
+  data_sources {
+    id       = "admin"
+    type     = "ADMIN"
+    host     = "preview.example.com"
+    port     = "5432"
+    username = "postgres"
+  }
+}
+
 ###############################################################################
 # Data Sources - Query existing instances
 ###############################################################################
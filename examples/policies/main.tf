@@ -35,3 +35,44 @@ data "bytebase_policy" "global_masking_policy" {
 output "global_masking_policy" {
   value = data.bytebase_policy.global_masking_policy
 }
+
+data "bytebase_policy" "project_tag_policy" {
+  parent = "projects/project-sample"
+  type   = "TAG"
+}
+
+output "project_tag_policy" {
+  value = data.bytebase_policy.project_tag_policy
+}
+
+# Resolve the query data policy for the database from its project and the workspace policies, the most restrictive value wins.
+data "bytebase_effective_policy" "database_query_data" {
+  parent = "instances/prod-sample-instance/databases/hr_prod"
+  type   = "DATA_QUERY"
+}
+
+output "database_query_data_policy" {
+  value = data.bytebase_effective_policy.database_query_data
+}
+
+# Manage a single masking rule without touching the other rules in the workspace policy.
+# Don't use it with the global_masking_policy in the bytebase_policy resource.
+resource "bytebase_masking_rule" "phone" {
+  rule_id       = "phone"
+  title         = "Mask the phone columns in prod"
+  condition     = "resource.environment_id == \"prod\" && resource.column_name == \"phone\""
+  semantic_type = "bb.default"
+  priority      = 0
+}
+
+# Grant a short-lived masking exemption without touching the other exemptions in the project policy.
+# Once it expires, the plan shows the exemption as inactive and the apply removes it from the policy.
+resource "bytebase_masking_exemption" "salary_audit" {
+  parent           = "projects/project-sample"
+  members          = ["user:ed@bytebase.com"]
+  database         = "instances/prod-sample-instance/databases/hr_prod"
+  table            = "salary"
+  columns          = ["amount"]
+  reason           = "Quarterly salary audit"
+  expire_timestamp = "2026-12-31T00:00:00Z"
+}
@@ -26,6 +26,13 @@ provider "bytebase" {
   # The Bytebase service URL. You can use the external URL in production.
   # Check the docs about external URL: https://www.bytebase.com/docs/get-started/install/external-url
   url = "https://bytebase.example.com"
+
+  # Optional: merge the default labels into the instance, database and project labels,
+  # and ignore the labels injected by automation.
+  # default_labels = {
+  #   team = "dba"
+  # }
+  # ignore_label_prefixes = ["automation/"]
 }
 
 resource "bytebase_setting" "workspace_profile" {
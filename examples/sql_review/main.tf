@@ -24,3 +24,16 @@ data "bytebase_review_config_list" "all" {
 output "all_review_configs" {
   value = data.bytebase_review_config_list.all
 }
+
+# Lint the migration files with the SQL review rules before rollout.
+data "bytebase_sql_check" "migrations" {
+  database = "instances/test-sample-instance/databases/employee"
+  files    = [for f in fileset(path.module, "migrations/*.sql") : "${path.module}/${f}"]
+}
+
+check "sql_review" {
+  assert {
+    condition     = data.bytebase_sql_check.migrations.passed
+    error_message = join("\n", [for a in data.bytebase_sql_check.migrations.advices : "${a.source}:${a.line} [${a.rule_type}] ${a.message}" if a.level == "ERROR"])
+  }
+}
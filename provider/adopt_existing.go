@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+const (
+	// adoptionCreate means the resource is created as a new object.
+	adoptionCreate = "CREATE"
+	// adoptionAdopt means the resource adopts the existing active object.
+	adoptionAdopt = "ADOPT"
+	// adoptionUndelete means the resource undeletes and adopts the existing soft-deleted object.
+	adoptionUndelete = "UNDELETE"
+)
+
+// existingObjectFinder returns the full name and the state of the existing object by the resource id.
+type existingObjectFinder func(ctx context.Context, c api.Client, id string) (string, v1pb.State, error)
+
+// getAdoptExistingSchema returns the adopt_existing schema for the resource.
+func getAdoptExistingSchema(resourceType string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: fmt.Sprintf("Adopt the existing %s with the same id on create, the soft-deleted %s is undeleted. The plan shows adoption = ADOPT or UNDELETE for the existing %s, check it before the apply. If false, the create fails when the %s already exists.", resourceType, resourceType, resourceType, resourceType),
+	}
+}
+
+// getAdoptionSchema returns the computed adoption schema for the resource.
+func getAdoptionSchema(resourceType string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: fmt.Sprintf("How the %s is created: CREATE for the new %s, ADOPT for the existing %s, UNDELETE for the soft-deleted %s. The plan shows ADOPT or UNDELETE if the existing %s is found at plan time, it's the only warning that the existing %s will be taken over.", resourceType, resourceType, resourceType, resourceType, resourceType, resourceType),
+	}
+}
+
+// getAdoption returns the adoption for the existing object.
+func getAdoption(exists bool, state v1pb.State) string {
+	if !exists {
+		return adoptionCreate
+	}
+	if state == v1pb.State_DELETED {
+		return adoptionUndelete
+	}
+	return adoptionAdopt
+}
+
+// getAdoptExistingError returns the actionable error for the existing object if the adoption is disabled.
+func getAdoptExistingError(resourceType, name string, state v1pb.State) error {
+	return errors.Errorf("the %s %s already exists in %s state, set adopt_existing = true to adopt it, or import it into the state", resourceType, name, state.String())
+}
+
+// checkAdoptExisting sets the adoption and returns the error if the object exists but adopt_existing is disabled.
+func checkAdoptExisting(d *schema.ResourceData, resourceType, name string, exists bool, state v1pb.State) diag.Diagnostics {
+	if exists && !d.Get("adopt_existing").(bool) {
+		return diag.FromErr(getAdoptExistingError(resourceType, name, state))
+	}
+	if err := d.Set("adoption", getAdoption(exists, state)); err != nil {
+		return diag.Errorf("cannot set adoption for %s: %s", resourceType, err.Error())
+	}
+	return nil
+}
+
+// validateAdoptExisting returns the CustomizeDiffFunc to find the existing object at plan time for the new resource.
+// The adoption is planned if the object exists, so the plan shows the object will be adopted or undeleted rather than created.
+func validateAdoptExisting(resourceType, idKey string, find existingObjectFinder) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		if diff.Id() != "" || !diff.NewValueKnown(idKey) {
+			return nil
+		}
+		id := diff.Get(idKey).(string)
+		if id == "" {
+			return nil
+		}
+		c, ok := m.(api.Client)
+		if !ok {
+			return nil
+		}
+
+		name, state, err := find(ctx, c, id)
+		if err != nil {
+			// The object is not found, it will be created.
+			if internal.IsNotFoundError(err) {
+				return nil
+			}
+			return errors.Wrapf(err, "failed to find the existing %s %s", resourceType, id)
+		}
+		if !diff.Get("adopt_existing").(bool) {
+			return getAdoptExistingError(resourceType, name, state)
+		}
+
+		// The CustomizeDiff cannot emit the warning diagnostic, and the provider logs are hidden by default,
+		// so the planned adoption is what tells the user the existing object will be adopted or undeleted.
+		adoption := getAdoption(true, state)
+		tflog.Info(ctx, fmt.Sprintf("the %s %s already exists in %s state, it will be adopted with %s instead of created", resourceType, name, state.String(), adoption))
+		return diff.SetNew("adoption", adoption)
+	}
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func TestAccAdoptExisting(t *testing.T) {
+	workspace := fmt.Sprintf("%s%s", internal.WorkspaceNamePrefix, internal.MockWorkspaceID)
+	tests := []struct {
+		resourceType string
+		resourceName string
+		// config returns the resource config with the extra attributes.
+		config       func(extra string) string
+		existingName string
+		checkDestroy resource.TestCheckFunc
+		// checkState checks the state of the undeleted object if the resource has the state attribute.
+		checkState bool
+	}{
+		{
+			resourceType: "user",
+			resourceName: "bytebase_user.adopt_user",
+			config: func(extra string) string {
+				return fmt.Sprintf(`
+resource "bytebase_user" "adopt_user" {
+	email    = "adopt-user@example.com"
+	title    = "Adopt User"
+	password = "SecureP@ssw0rd!"
+	%s
+}
+`, extra)
+			},
+			existingName: "users/adopt-user@example.com",
+			checkDestroy: testAccCheckUserDestroy,
+		},
+		{
+			resourceType: "service account",
+			resourceName: "bytebase_service_account.adopt_sa",
+			config: func(extra string) string {
+				return fmt.Sprintf(`
+resource "bytebase_service_account" "adopt_sa" {
+	parent             = "%s"
+	service_account_id = "adopt-sa"
+	title              = "Adopt Service Account"
+	%s
+}
+`, workspace, extra)
+			},
+			existingName: "serviceAccounts/adopt-sa@service.bytebase.com",
+			checkDestroy: testAccCheckServiceAccountDestroy,
+			checkState:   true,
+		},
+		{
+			resourceType: "workload identity",
+			resourceName: "bytebase_workload_identity.adopt_wi",
+			config: func(extra string) string {
+				return fmt.Sprintf(`
+resource "bytebase_workload_identity" "adopt_wi" {
+	parent               = "%s"
+	workload_identity_id = "adopt-wi"
+	title                = "Adopt Workload Identity"
+	%s
+}
+`, workspace, extra)
+			},
+			existingName: "workloadIdentities/adopt-wi@workload.bytebase.com",
+			checkDestroy: testAccCheckWorkloadIdentityDestroy,
+			checkState:   true,
+		},
+		{
+			resourceType: "project",
+			resourceName: "bytebase_project.adopt_project",
+			config: func(extra string) string {
+				return fmt.Sprintf(`
+resource "bytebase_project" "adopt_project" {
+	resource_id         = "adopt-project"
+	title               = "adopt project"
+	deletion_protection = false
+	%s
+}
+`, extra)
+			},
+			existingName: "projects/adopt-project",
+			checkDestroy: testAccCheckProjectDestroy,
+		},
+		{
+			resourceType: "instance",
+			resourceName: "bytebase_instance.adopt_instance",
+			config: func(extra string) string {
+				return fmt.Sprintf(`
+resource "bytebase_instance" "adopt_instance" {
+	resource_id = "adopt-instance"
+	title       = "adopt instance"
+	engine      = "POSTGRES"
+	environment = "environments/test"
+
+	data_sources {
+		id       = "admin data source"
+		type     = "ADMIN"
+		username = "bytebase"
+		host     = "127.0.0.1"
+		port     = "5432"
+	}
+
+	deletion_protection = false
+	%s
+}
+`, extra)
+			},
+			existingName: "instances/adopt-instance",
+			checkDestroy: testAccCheckInstanceDestroy,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.resourceType, func(t *testing.T) {
+			undeletedChecks := []resource.TestCheckFunc{
+				internal.TestCheckResourceExists(test.resourceName),
+				resource.TestCheckResourceAttr(test.resourceName, "adoption", adoptionUndelete),
+				resource.TestCheckResourceAttr(test.resourceName, "adopt_existing", "true"),
+			}
+			if test.checkState {
+				undeletedChecks = append(undeletedChecks, resource.TestCheckResourceAttr(test.resourceName, "state", v1pb.State_ACTIVE.String()))
+			}
+
+			resource.Test(t, resource.TestCase{
+				PreCheck: func() {
+					testAccPreCheck(t)
+				},
+				Providers:    testAccProviders,
+				CheckDestroy: test.checkDestroy,
+				Steps: []resource.TestStep{
+					{
+						Config: test.config(""),
+						Check: resource.ComposeTestCheckFunc(
+							internal.TestCheckResourceExists(test.resourceName),
+							resource.TestCheckResourceAttr(test.resourceName, "adoption", adoptionCreate),
+						),
+					},
+					// soft delete the object
+					{
+						Config: `locals {}`,
+					},
+					// the soft-deleted object is found at plan time
+					{
+						Config:      test.config("adopt_existing = false"),
+						ExpectError: regexp.MustCompile(regexp.QuoteMeta(fmt.Sprintf("the %s %s already exists in DELETED state, set adopt_existing = true to adopt it", test.resourceType, test.existingName))),
+					},
+					{
+						Config: test.config(""),
+						Check:  resource.ComposeTestCheckFunc(undeletedChecks...),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+const (
+	// auditLogPayloadMaxLength is the max length for the request and response summary in the audit log.
+	auditLogPayloadMaxLength = 1024
+	// auditLogDefaultLimit is the default count of audit logs to return.
+	auditLogDefaultLimit = 100
+	// auditLogMaxLimit is the max count of audit logs to return, to keep the state size bounded.
+	auditLogMaxLimit = 5000
+)
+
+func dataSourceAuditLogList() *schema.Resource {
+	return &schema.Resource{
+		Description:        "The audit log data source list. The audit logs are ordered by the create time descending.",
+		ReadWithoutTimeout: dataSourceAuditLogListRead,
+		Schema: map[string]*schema.Schema{
+			"parent": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateDiagFunc: internal.ResourceNameValidation(
+					// allow empty to default to workspace
+					"^$",
+					fmt.Sprintf("^%s%s$", internal.WorkspaceNamePrefix, internal.ResourceIDPattern),
+					fmt.Sprintf("^%s%s$", internal.ProjectNamePrefix, internal.ResourceIDPattern),
+				),
+				Description: "The parent resource. Format: workspaces/{workspace id} or projects/{project id}. Defaults to the workspace if not specified.",
+			},
+			"method": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Filter audit logs by the API method, for example /bytebase.v1.ProjectService/SetIamPolicy.",
+			},
+			"resource": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Filter audit logs by the resource full name, for example projects/sample-project.",
+			},
+			"user": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Filter audit logs by the user full name in users/{email} format.",
+				ValidateDiagFunc: internal.ResourceNameValidation(
+					fmt.Sprintf(`^%s\S+$`, internal.UserNamePrefix),
+				),
+			},
+			"severity": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(getEnumNames(v1pb.AuditLog_Severity_name, v1pb.AuditLog_SEVERITY_UNSPECIFIED.String()), false),
+				Description:  "Filter audit logs by severity, for example INFO or ERROR.",
+			},
+			"create_time_after":  getCreateTimeAfterSchema(),
+			"create_time_before": getCreateTimeBeforeSchema(),
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      auditLogDefaultLimit,
+				ValidateFunc: validation.IntBetween(1, auditLogMaxLimit),
+				Description:  fmt.Sprintf("The maximum count of audit logs to return, between 1 and %d. Default %d.", auditLogMaxLimit, auditLogDefaultLimit),
+			},
+			"audit_logs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The audit log full name.",
+						},
+						"create_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The audit log create time.",
+						},
+						"user": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The user who performs the action in users/{email} format.",
+						},
+						"method": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The API method.",
+						},
+						"severity": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The audit log severity.",
+						},
+						"resource": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The resource associated with the action.",
+						},
+						"request": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: fmt.Sprintf("The request summary in JSON, truncated to %d characters.", auditLogPayloadMaxLength),
+						},
+						"response": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: fmt.Sprintf("The response summary in JSON, truncated to %d characters.", auditLogPayloadMaxLength),
+						},
+						"status_code": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The response status code, 0 means OK.",
+						},
+						"status_message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The response status message.",
+						},
+						"latency_ms": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The request latency in milliseconds.",
+						},
+						"caller_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IP address of the caller.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAuditLogListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	parent := internal.ResolveWorkspaceParent(d.Get("parent").(string), c.GetWorkspaceName())
+
+	after, before, err := getCreateTimeRange(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	filter := &api.AuditLogFilter{
+		Method:       d.Get("method").(string),
+		Resource:     d.Get("resource").(string),
+		User:         d.Get("user").(string),
+		CreateAfter:  after,
+		CreateBefore: before,
+		Limit:        d.Get("limit").(int),
+	}
+	if v, ok := v1pb.AuditLog_Severity_value[d.Get("severity").(string)]; ok {
+		filter.Severity = v1pb.AuditLog_Severity(v)
+	}
+
+	auditLogs, err := c.SearchAuditLogs(ctx, parent, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	auditLogList := []interface{}{}
+	for _, auditLog := range auditLogs {
+		raw := map[string]interface{}{
+			"name":           auditLog.GetName(),
+			"user":           auditLog.GetUser(),
+			"method":         auditLog.GetMethod(),
+			"severity":       auditLog.GetSeverity().String(),
+			"resource":       auditLog.GetResource(),
+			"request":        truncateString(auditLog.GetRequest(), auditLogPayloadMaxLength),
+			"response":       truncateString(auditLog.GetResponse(), auditLogPayloadMaxLength),
+			"status_code":    int(auditLog.GetStatus().GetCode()),
+			"status_message": auditLog.GetStatus().GetMessage(),
+			"latency_ms":     int(auditLog.GetLatency().AsDuration().Milliseconds()),
+			"caller_ip":      auditLog.GetRequestMetadata().GetCallerIp(),
+		}
+		if v := auditLog.GetCreateTime(); v != nil {
+			raw["create_time"] = v.AsTime().UTC().Format(time.RFC3339)
+		}
+		auditLogList = append(auditLogList, raw)
+	}
+
+	if err := d.Set("parent", parent); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("audit_logs", auditLogList); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// always refresh
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return nil
+}
+
+// truncateString truncates the string to the max characters.
+func truncateString(s string, maxLength int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLength {
+		return s
+	}
+	return string(runes[:maxLength]) + "..."
+}
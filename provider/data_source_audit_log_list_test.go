@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func TestAccAuditLogListDataSource(t *testing.T) {
+	dataSourceName := "data.bytebase_audit_log_list.logs"
+	logPrefix := fmt.Sprintf("%s%s/auditLogs", internal.WorkspaceNamePrefix, internal.MockWorkspaceID)
+	getConfig := func(filter string) string {
+		return fmt.Sprintf(`
+data "bytebase_audit_log_list" "logs" {
+	%s
+}
+`, filter)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// list the workspace audit logs with the default limit
+			{
+				Config: getConfig(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "parent", fmt.Sprintf("%s%s", internal.WorkspaceNamePrefix, internal.MockWorkspaceID)),
+					resource.TestCheckResourceAttr(dataSourceName, "limit", "100"),
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.#", "3"),
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.0.name", fmt.Sprintf("%s/3", logPrefix)),
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.0.create_time", "2025-01-03T00:00:00Z"),
+				),
+			},
+			// filter by method
+			{
+				Config: getConfig(`method = "/bytebase.v1.ProjectService/SetIamPolicy"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.0.name", fmt.Sprintf("%s/2", logPrefix)),
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.1.name", fmt.Sprintf("%s/1", logPrefix)),
+				),
+			},
+			// filter by the resource with quotes
+			{
+				Config: getConfig(`resource = "projects/sample-\"project\""`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.0.name", fmt.Sprintf("%s/2", logPrefix)),
+				),
+			},
+			// filter by user
+			{
+				Config: getConfig(`user = "users/dba@bytebase.com"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.0.user", "users/dba@bytebase.com"),
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.1.user", "users/dba@bytebase.com"),
+				),
+			},
+			// filter by severity
+			{
+				Config: getConfig(`severity = "ERROR"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.0.severity", "ERROR"),
+				),
+			},
+			// filter by the create time range
+			{
+				Config: getConfig(`
+	create_time_after  = "2025-01-02T00:00:00Z"
+	create_time_before = "2025-01-02T12:00:00Z"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.0.name", fmt.Sprintf("%s/2", logPrefix)),
+				),
+			},
+			// limit the count
+			{
+				Config: getConfig(`limit = 1`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.0.name", fmt.Sprintf("%s/3", logPrefix)),
+				),
+			},
+		},
+	})
+}
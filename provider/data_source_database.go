@@ -72,77 +72,81 @@ func dataSourceDatabase() *schema.Resource {
 				Description: "The deployment and policy control labels.",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
-			"catalog": {
-				Type:        schema.TypeList,
-				Computed:    true,
-				Description: "The databases catalog.",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"schemas": {
-							Computed: true,
-							Type:     schema.TypeSet,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"name": {
-										Type:     schema.TypeString,
-										Computed: true,
-									},
-									"tables": {
-										Computed: true,
-										Type:     schema.TypeSet,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"name": {
-													Type:     schema.TypeString,
-													Computed: true,
-												},
-												"classification": {
-													Type:        schema.TypeString,
-													Computed:    true,
-													Description: "The classification id",
-												},
-												"object_schema_json": {
-													Type:        schema.TypeString,
-													Computed:    true,
-													Description: "JSON-encoded ObjectSchema for document-oriented databases.",
-												},
-												"columns": {
-													Computed: true,
-													Type:     schema.TypeSet,
-													Elem: &schema.Resource{
-														Schema: map[string]*schema.Schema{
-															"name": {
-																Type:     schema.TypeString,
-																Computed: true,
-															},
-															"semantic_type": {
-																Type:        schema.TypeString,
-																Computed:    true,
-																Description: "The semantic type id",
-															},
-															"classification": {
-																Type:        schema.TypeString,
-																Computed:    true,
-																Description: "The classification id",
-															},
-															"labels": {
-																Type:     schema.TypeMap,
-																Computed: true,
-																Elem:     &schema.Schema{Type: schema.TypeString},
-															},
-														},
+			"catalog": getDatabaseCatalogComputedSchema(),
+		},
+	}
+}
+
+func getDatabaseCatalogComputedSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The databases catalog.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"schemas": {
+					Computed: true,
+					Type:     schema.TypeSet,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"tables": {
+								Computed: true,
+								Type:     schema.TypeSet,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"name": {
+											Type:     schema.TypeString,
+											Computed: true,
+										},
+										"classification": {
+											Type:        schema.TypeString,
+											Computed:    true,
+											Description: "The classification id",
+										},
+										"object_schema_json": {
+											Type:        schema.TypeString,
+											Computed:    true,
+											Description: "JSON-encoded ObjectSchema for document-oriented databases.",
+										},
+										"columns": {
+											Computed: true,
+											Type:     schema.TypeSet,
+											Elem: &schema.Resource{
+												Schema: map[string]*schema.Schema{
+													"name": {
+														Type:     schema.TypeString,
+														Computed: true,
+													},
+													"semantic_type": {
+														Type:        schema.TypeString,
+														Computed:    true,
+														Description: "The semantic type id",
+													},
+													"classification": {
+														Type:        schema.TypeString,
+														Computed:    true,
+														Description: "The classification id",
+													},
+													"labels": {
+														Type:     schema.TypeMap,
+														Computed: true,
+														Elem:     &schema.Schema{Type: schema.TypeString},
 													},
-													Set: columnHash,
 												},
 											},
+											Set: columnHash,
 										},
-										Set: tableHash,
 									},
 								},
+								Set: tableHash,
 							},
-							Set: schemaHash,
 						},
 					},
+					Set: schemaHash,
 				},
 			},
 		},
@@ -0,0 +1,270 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func dataSourceDatabaseChangelogList() *schema.Resource {
+	return &schema.Resource{
+		Description:        "The database changelog data source list.",
+		ReadWithoutTimeout: dataSourceDatabaseChangelogListRead,
+		Schema: map[string]*schema.Schema{
+			"database": getDatabaseNameFilterSchema(),
+			"types": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(getEnumNames(v1pb.Changelog_Type_name, v1pb.Changelog_TYPE_UNSPECIFIED.String()), false),
+				},
+				Description: "Filter changelogs by types, for example MIGRATE, SDL or BASELINE.",
+			},
+			"statuses": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(getEnumNames(v1pb.Changelog_Status_name, v1pb.Changelog_STATUS_UNSPECIFIED.String()), false),
+				},
+				Description: "Filter changelogs by statuses, for example DONE or FAILED.",
+			},
+			"create_time_after":  getCreateTimeAfterSchema(),
+			"create_time_before": getCreateTimeBeforeSchema(),
+			"include_statement_sha256": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Compute the statement_sha256 for the changelogs. It downloads the full statement of every changelog, which can be a very large payload for the database with the long history.",
+			},
+			"changelogs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The changelog full name in instances/{instance}/databases/{database}/changelogs/{changelog} format.",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The schema version applied by the changelog.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The changelog type.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The changelog status.",
+						},
+						"statement_sha256": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The sha256 hex digest of the applied statement. It's empty unless the include_statement_sha256 is true.",
+						},
+						"statement_size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The size of the applied statement in bytes.",
+						},
+						"issue": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The issue full name in projects/{project}/issues/{issue} format.",
+						},
+						"creator": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The creator of the issue which applied the changelog in users/{email} format. The changelog has no creator, so it's empty if the changelog is not applied through an issue.",
+						},
+						"task_run": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The task run full name which applied the change.",
+						},
+						"create_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The changelog create time.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getDatabaseNameFilterSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "The database full name in instances/{instance}/databases/{database} format.",
+		ValidateDiagFunc: internal.ResourceNameValidation(
+			// database name format
+			fmt.Sprintf(`^%s%s/%s\S+$`, internal.InstanceNamePrefix, internal.ResourceIDPattern, internal.DatabaseIDPrefix),
+		),
+	}
+}
+
+func getCreateTimeAfterSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.IsRFC3339Time,
+		Description:  "Only return items created at or after the time, in RFC 3339 format like 2025-01-01T00:00:00Z.",
+	}
+}
+
+func getCreateTimeBeforeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.IsRFC3339Time,
+		Description:  "Only return items created at or before the time, in RFC 3339 format like 2025-12-31T23:59:59Z.",
+	}
+}
+
+// getCreateTimeRange parses the create_time_after and create_time_before fields, the zero time means no bound.
+func getCreateTimeRange(d *schema.ResourceData) (time.Time, time.Time, error) {
+	var after, before time.Time
+	if v := d.Get("create_time_after").(string); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return after, before, errors.Wrapf(err, "invalid create_time_after %s", v)
+		}
+		after = t
+	}
+	if v := d.Get("create_time_before").(string); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return after, before, errors.Wrapf(err, "invalid create_time_before %s", v)
+		}
+		before = t
+	}
+	if !after.IsZero() && !before.IsZero() && after.After(before) {
+		return after, before, errors.Errorf("create_time_after %s must not be later than create_time_before %s", after.Format(time.RFC3339), before.Format(time.RFC3339))
+	}
+	return after, before, nil
+}
+
+// getEnumNames returns the sorted enum names except the excluded ones.
+func getEnumNames(enumNames map[int32]string, excludes ...string) []string {
+	names := []string{}
+	for _, name := range enumNames {
+		if slices.Contains(excludes, name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func getStatementSHA256(statement string) string {
+	if statement == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(statement))
+	return hex.EncodeToString(sum[:])
+}
+
+// getIssueCreator returns the creator of the issue, the empty issue or the deleted issue has no creator.
+func getIssueCreator(ctx context.Context, client api.Client, issueName string, issueCreators map[string]string) (string, error) {
+	if issueName == "" {
+		return "", nil
+	}
+	if creator, ok := issueCreators[issueName]; ok {
+		return creator, nil
+	}
+	issue, err := client.GetIssue(ctx, issueName)
+	if err != nil && !internal.IsNotFoundError(err) {
+		return "", errors.Wrapf(err, "failed to get issue %s", issueName)
+	}
+	creator := issue.GetCreator()
+	issueCreators[issueName] = creator
+	return creator, nil
+}
+
+func dataSourceDatabaseChangelogListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+
+	after, before, err := getCreateTimeRange(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	filter := &api.ChangelogFilter{
+		CreateAfter:   after,
+		CreateBefore:  before,
+		FullStatement: d.Get("include_statement_sha256").(bool),
+	}
+	for _, raw := range d.Get("types").(*schema.Set).List() {
+		if v, ok := v1pb.Changelog_Type_value[raw.(string)]; ok {
+			filter.Types = append(filter.Types, v1pb.Changelog_Type(v))
+		}
+	}
+	for _, raw := range d.Get("statuses").(*schema.Set).List() {
+		if v, ok := v1pb.Changelog_Status_value[raw.(string)]; ok {
+			filter.Statuses = append(filter.Statuses, v1pb.Changelog_Status(v))
+		}
+	}
+
+	changelogs, err := c.ListChangelogs(ctx, d.Get("database").(string), filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// issueCreators caches the issue creator, multiple changelogs can be applied by the same issue.
+	issueCreators := map[string]string{}
+	changelogList := []interface{}{}
+	for _, changelog := range changelogs {
+		creator, err := getIssueCreator(ctx, c, changelog.GetIssue(), issueCreators)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		raw := map[string]interface{}{
+			"name":             changelog.GetName(),
+			"version":          changelog.GetVersion(),
+			"type":             changelog.GetType().String(),
+			"status":           changelog.GetStatus().String(),
+			"statement_sha256": getStatementSHA256(changelog.GetStatement()),
+			"statement_size":   int(changelog.GetStatementSize()),
+			"issue":            changelog.GetIssue(),
+			"creator":          creator,
+			"task_run":         changelog.GetTaskRun(),
+		}
+		if v := changelog.GetCreateTime(); v != nil {
+			raw["create_time"] = v.AsTime().UTC().Format(time.RFC3339)
+		}
+		changelogList = append(changelogList, raw)
+	}
+
+	if err := d.Set("changelogs", changelogList); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// always refresh
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return nil
+}
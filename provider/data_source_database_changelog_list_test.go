@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatabaseChangelogListDataSource(t *testing.T) {
+	instanceID := "changelog-list-instance"
+	databaseName := fmt.Sprintf("instances/%s/databases/test-database", instanceID)
+	dataSourceName := "data.bytebase_database_changelog_list.changelogs"
+	getConfig := func(filter string) string {
+		return fmt.Sprintf(`
+%s
+
+data "bytebase_database_changelog_list" "changelogs" {
+	database   = "${bytebase_instance.changelog_list_instance.name}/databases/test-database"
+	%s
+	depends_on = [bytebase_instance.changelog_list_instance]
+}
+`, testAccCheckInstanceResource("changelog_list_instance", instanceID, "changelog list instance", "POSTGRES", "environments/test"), filter)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// list all changelogs with the issue creator and the statement hash
+			{
+				Config: getConfig("include_statement_sha256 = true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.#", "3"),
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.0.name", fmt.Sprintf("%s/changelogs/1", databaseName)),
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.0.version", "0001"),
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.0.issue", "projects/mock-history/issues/1"),
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.0.creator", "users/dev@bytebase.com"),
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.0.statement_sha256", getStatementSHA256("CREATE TABLE t1 (id INT);")),
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.0.create_time", "2025-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.1.creator", "users/dba@bytebase.com"),
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.2.issue", ""),
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.2.creator", ""),
+				),
+			},
+			// the statement hash is opt-in
+			{
+				Config: getConfig(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.#", "3"),
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.0.statement_sha256", ""),
+					resource.TestCheckResourceAttrSet(dataSourceName, "changelogs.0.statement_size"),
+				),
+			},
+			// filter by types
+			{
+				Config: getConfig(`types = ["SDL"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.0.type", "SDL"),
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.0.version", "0002"),
+				),
+			},
+			// filter by statuses
+			{
+				Config: getConfig(`statuses = ["FAILED"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.0.status", "FAILED"),
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.0.version", "0003"),
+				),
+			},
+			// filter by the create time range, both bounds are inclusive
+			{
+				Config: getConfig(`
+	create_time_after  = "2025-02-01T00:00:00Z"
+	create_time_before = "2025-03-01T00:00:00Z"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.0.version", "0002"),
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.1.version", "0003"),
+				),
+			},
+			// combine the filters
+			{
+				Config: getConfig(`
+	types             = ["MIGRATE"]
+	statuses          = ["DONE", "FAILED"]
+	create_time_after = "2025-01-15T00:00:00Z"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "changelogs.0.version", "0003"),
+				),
+			},
+		},
+	})
+}
@@ -3,11 +3,17 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
 
 	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
 
@@ -82,6 +88,40 @@ func dataSourceDatabaseList() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Description: "Filter databases by labels",
 			},
+			"include_catalog": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Fetch the catalog for each database. The catalogs are fetched in parallel bounded by catalog_parallelism.",
+			},
+			"catalog_parallelism": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntBetween(1, 50),
+				Description:  "The max count of the catalogs to fetch in parallel.",
+			},
+			"catalog_schemas": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Only include the schemas in the catalog. Include all schemas if not set.",
+			},
+			"catalog_tables": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Only include the tables in the catalog. Include all tables if not set.",
+			},
+			"fields": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(databaseListFields, false),
+				},
+				Description: "Only return the fields besides the database name, to reduce the state size for a large fleet. Return all fields if not set.",
+			},
 			"databases": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -144,6 +184,7 @@ func dataSourceDatabaseList() *schema.Resource {
 							Description: "The deployment and policy control labels.",
 							Elem:        &schema.Schema{Type: schema.TypeString},
 						},
+						"catalog": getDatabaseCatalogComputedSchema(),
 					},
 				},
 			},
@@ -183,25 +224,69 @@ func dataSourceDatabaseListRead(ctx context.Context, d *schema.ResourceData, m i
 		return diag.FromErr(err)
 	}
 
+	fields := map[string]bool{}
+	for _, field := range d.Get("fields").(*schema.Set).List() {
+		fields[field.(string)] = true
+	}
+	// Return all fields if not set.
+	if len(fields) == 0 {
+		for _, field := range databaseListFields {
+			fields[field] = true
+		}
+	}
+
+	var catalogs []*v1pb.DatabaseCatalog
+	if d.Get("include_catalog").(bool) {
+		catalogs, err = listDatabaseCatalogs(ctx, client, databases, d.Get("catalog_parallelism").(int))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	catalogSchemas := convertToStringList(d.Get("catalog_schemas").(*schema.Set))
+	catalogTables := convertToStringList(d.Get("catalog_tables").(*schema.Set))
+
 	dbList := []interface{}{}
-	for _, database := range databases {
+	for i, database := range databases {
 		db := map[string]interface{}{}
 		db["name"] = database.Name
-		db["project"] = database.Project
+		if fields["project"] {
+			db["project"] = database.Project
+		}
 		if v := database.EffectiveEnvironment; v != nil {
-			db["environment"] = *v
-			db["effective_environment"] = *v
+			if fields["environment"] {
+				db["environment"] = *v
+			}
+			if fields["effective_environment"] {
+				db["effective_environment"] = *v
+			}
+		}
+		if fields["state"] {
+			db["state"] = database.State.String()
 		}
-		db["state"] = database.State.String()
-		if database.SuccessfulSyncTime != nil {
+		if fields["successful_sync_time"] && database.SuccessfulSyncTime != nil {
 			db["successful_sync_time"] = database.SuccessfulSyncTime.AsTime().UTC().Format(time.RFC3339)
 		}
-		db["release"] = database.Release
-		db["instance_resource"] = flattenDatabaseInstanceResource(database.InstanceResource)
-		db["backup_available"] = database.BackupAvailable
-		db["sync_status"] = database.SyncStatus.String()
-		db["sync_error"] = database.SyncError
-		db["labels"] = database.Labels
+		if fields["release"] {
+			db["release"] = database.Release
+		}
+		if fields["instance_resource"] {
+			db["instance_resource"] = flattenDatabaseInstanceResource(database.InstanceResource)
+		}
+		if fields["backup_available"] {
+			db["backup_available"] = database.BackupAvailable
+		}
+		if fields["sync_status"] {
+			db["sync_status"] = database.SyncStatus.String()
+		}
+		if fields["sync_error"] {
+			db["sync_error"] = database.SyncError
+		}
+		if fields["labels"] {
+			db["labels"] = database.Labels
+		}
+		if catalogs != nil {
+			db["catalog"] = flattenDatabaseCatalog(filterDatabaseCatalogByName(catalogs[i], catalogSchemas, catalogTables))
+		}
 
 		dbList = append(dbList, db)
 	}
@@ -215,3 +300,88 @@ func dataSourceDatabaseListRead(ctx context.Context, d *schema.ResourceData, m i
 
 	return nil
 }
+
+// databaseListFields is the database fields can be projected in the database list data source.
+var databaseListFields = []string{
+	"project",
+	"environment",
+	"state",
+	"successful_sync_time",
+	"release",
+	"effective_environment",
+	"instance_resource",
+	"backup_available",
+	"sync_status",
+	"sync_error",
+	"labels",
+}
+
+// listDatabaseCatalogs gets the catalogs for the databases with bounded parallelism.
+// The catalogs are returned in the same order as the databases.
+func listDatabaseCatalogs(ctx context.Context, client api.Client, databases []*v1pb.Database, parallelism int) ([]*v1pb.DatabaseCatalog, error) {
+	catalogs := make([]*v1pb.DatabaseCatalog, len(databases))
+	errs := make([]error, len(databases))
+	startTime := time.Now()
+
+	var wg sync.WaitGroup
+	var fetched atomic.Int64
+	semaphore := make(chan struct{}, parallelism)
+	for i, database := range databases {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			catalog, err := client.GetDatabaseCatalog(ctx, database.Name)
+			if err != nil {
+				errs[i] = errors.Wrapf(err, "failed to get catalog for database %s", database.Name)
+				return
+			}
+			catalogs[i] = catalog
+
+			if count := fetched.Add(1); count%100 == 0 || int(count) == len(databases) {
+				tflog.Info(ctx, "[list database catalogs]", map[string]interface{}{
+					"fetched": count,
+					"total":   len(databases),
+					"ms":      time.Since(startTime).Milliseconds(),
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return catalogs, nil
+}
+
+// filterDatabaseCatalogByName returns the catalog with only the schemas and tables in the list.
+// Empty list means no filter.
+func filterDatabaseCatalogByName(catalog *v1pb.DatabaseCatalog, schemaNames, tableNames []string) *v1pb.DatabaseCatalog {
+	if len(schemaNames) == 0 && len(tableNames) == 0 {
+		return catalog
+	}
+	filtered := &v1pb.DatabaseCatalog{
+		Name: catalog.Name,
+	}
+	for _, schemaCatalog := range catalog.Schemas {
+		if len(schemaNames) > 0 && !slices.Contains(schemaNames, schemaCatalog.Name) {
+			continue
+		}
+		filteredSchema := &v1pb.SchemaCatalog{
+			Name: schemaCatalog.Name,
+		}
+		for _, table := range schemaCatalog.Tables {
+			if len(tableNames) > 0 && !slices.Contains(tableNames, table.Name) {
+				continue
+			}
+			filteredSchema.Tables = append(filteredSchema.Tables, table)
+		}
+		filtered.Schemas = append(filtered.Schemas, filteredSchema)
+	}
+	return filtered
+}
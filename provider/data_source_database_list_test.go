@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+)
+
+func TestAccDatabaseListDataSource_IncludeCatalog(t *testing.T) {
+	instanceID := "database-list-instance"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "bytebase_database_column_catalog" "email" {
+	database      = "${bytebase_instance.database_list_instance.name}/databases/test-database"
+	schema        = "public"
+	table         = "users"
+	column        = "email"
+	semantic_type = "email-mask"
+}
+
+data "bytebase_database_list" "all" {
+	parent              = bytebase_instance.database_list_instance.name
+	query               = "test-database"
+	include_catalog     = true
+	catalog_parallelism = 2
+	catalog_tables      = ["users"]
+	fields              = ["project"]
+	depends_on          = [bytebase_database_column_catalog.email]
+}
+`, testAccCheckInstanceResource("database_list_instance", instanceID, "database list instance", "POSTGRES", "environments/test")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bytebase_database_list.all", "databases.#", "2"),
+					resource.TestCheckResourceAttr("data.bytebase_database_list.all", "databases.0.name", fmt.Sprintf("instances/%s/databases/test-database", instanceID)),
+					resource.TestCheckResourceAttr("data.bytebase_database_list.all", "databases.0.catalog.0.schemas.#", "1"),
+					resource.TestCheckResourceAttr("data.bytebase_database_list.all", "databases.0.labels.%", "0"),
+					resource.TestCheckResourceAttr("data.bytebase_database_list.all", "databases.1.catalog.0.schemas.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestFilterDatabaseCatalogByName(t *testing.T) {
+	catalog := &v1pb.DatabaseCatalog{
+		Name: "instances/x/databases/y/catalog",
+		Schemas: []*v1pb.SchemaCatalog{
+			{
+				Name: "public",
+				Tables: []*v1pb.TableCatalog{
+					{Name: "users"},
+					{Name: "orders"},
+				},
+			},
+			{
+				Name:   "audit",
+				Tables: []*v1pb.TableCatalog{{Name: "users"}},
+			},
+		},
+	}
+
+	if got := filterDatabaseCatalogByName(catalog, nil, nil); got != catalog {
+		t.Fatal("filterDatabaseCatalogByName() without filters should return the catalog")
+	}
+
+	filtered := filterDatabaseCatalogByName(catalog, []string{"public"}, []string{"users"})
+	if len(filtered.Schemas) != 1 || filtered.Schemas[0].Name != "public" {
+		t.Fatalf("expected only the public schema, got %v", filtered.Schemas)
+	}
+	if len(filtered.Schemas[0].Tables) != 1 || filtered.Schemas[0].Tables[0].Name != "users" {
+		t.Fatalf("expected only the users table, got %v", filtered.Schemas[0].Tables)
+	}
+
+	filtered = filterDatabaseCatalogByName(catalog, nil, []string{"users"})
+	if len(filtered.Schemas) != 2 {
+		t.Fatalf("expected the users table in both schemas, got %v", filtered.Schemas)
+	}
+}
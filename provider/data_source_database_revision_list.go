@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func dataSourceDatabaseRevisionList() *schema.Resource {
+	return &schema.Resource{
+		Description:        "The database revision data source list.",
+		ReadWithoutTimeout: dataSourceDatabaseRevisionListRead,
+		Schema: map[string]*schema.Schema{
+			"database": getDatabaseNameFilterSchema(),
+			"release": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The release full name in projects/{project}/releases/{release} format. Filter revisions by release.",
+				ValidateDiagFunc: internal.ResourceNameValidation(
+					fmt.Sprintf(`^%s%s/releases/\S+$`, internal.ProjectNamePrefix, internal.ResourceIDPattern),
+				),
+			},
+			"show_deleted": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Include the deleted revisions.",
+			},
+			"create_time_after":  getCreateTimeAfterSchema(),
+			"create_time_before": getCreateTimeBeforeSchema(),
+			"revisions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The revision full name in instances/{instance}/databases/{database}/revisions/{revision} format.",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The revision version.",
+						},
+						"release": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The release full name which the revision belongs to.",
+						},
+						"file": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The release file full name which the revision applied.",
+						},
+						"statement_sha256": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The sha256 hex digest of the applied statement.",
+						},
+						"issue": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The issue full name in projects/{project}/issues/{issue} format.",
+						},
+						"creator": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The creator of the revision in users/{email} format.",
+						},
+						"create_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The revision create time.",
+						},
+						"delete_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The revision delete time, only set for deleted revisions.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDatabaseRevisionListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+
+	after, before, err := getCreateTimeRange(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	filter := &api.RevisionFilter{
+		Release:      d.Get("release").(string),
+		ShowDeleted:  d.Get("show_deleted").(bool),
+		CreateAfter:  after,
+		CreateBefore: before,
+	}
+
+	revisions, err := c.ListRevisions(ctx, d.Get("database").(string), filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	revisionList := []interface{}{}
+	for _, revision := range revisions {
+		statementSHA256 := revision.GetSheetSha256()
+		if statementSHA256 == "" {
+			statementSHA256 = getStatementSHA256(revision.GetStatement())
+		}
+		raw := map[string]interface{}{
+			"name":             revision.GetName(),
+			"version":          revision.GetVersion(),
+			"release":          revision.GetRelease(),
+			"file":             revision.GetFile(),
+			"statement_sha256": statementSHA256,
+			"issue":            revision.GetIssue(),
+			"creator":          revision.GetCreator(),
+		}
+		if v := revision.GetCreateTime(); v != nil {
+			raw["create_time"] = v.AsTime().UTC().Format(time.RFC3339)
+		}
+		if v := revision.GetDeleteTime(); v != nil {
+			raw["delete_time"] = v.AsTime().UTC().Format(time.RFC3339)
+		}
+		revisionList = append(revisionList, raw)
+	}
+
+	if err := d.Set("revisions", revisionList); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// always refresh
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return nil
+}
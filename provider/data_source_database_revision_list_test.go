@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatabaseRevisionListDataSource(t *testing.T) {
+	instanceID := "revision-list-instance"
+	databaseName := fmt.Sprintf("instances/%s/databases/test-database", instanceID)
+	dataSourceName := "data.bytebase_database_revision_list.revisions"
+	getConfig := func(filter string) string {
+		return fmt.Sprintf(`
+%s
+
+data "bytebase_database_revision_list" "revisions" {
+	database   = "${bytebase_instance.revision_list_instance.name}/databases/test-database"
+	%s
+	depends_on = [bytebase_instance.revision_list_instance]
+}
+`, testAccCheckInstanceResource("revision_list_instance", instanceID, "revision list instance", "POSTGRES", "environments/test"), filter)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// list the revisions without the deleted ones
+			{
+				Config: getConfig(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "revisions.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "revisions.0.name", fmt.Sprintf("%s/revisions/1", databaseName)),
+					resource.TestCheckResourceAttr(dataSourceName, "revisions.0.release", "projects/mock-history/releases/r1"),
+					resource.TestCheckResourceAttr(dataSourceName, "revisions.0.creator", "users/dev@bytebase.com"),
+					resource.TestCheckResourceAttr(dataSourceName, "revisions.0.issue", "projects/mock-history/issues/1"),
+					resource.TestCheckResourceAttr(dataSourceName, "revisions.0.statement_sha256", getStatementSHA256("CREATE TABLE t1 (id INT);")),
+					resource.TestCheckResourceAttr(dataSourceName, "revisions.0.create_time", "2025-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr(dataSourceName, "revisions.1.creator", "users/dba@bytebase.com"),
+				),
+			},
+			// include the deleted revisions
+			{
+				Config: getConfig(`show_deleted = true`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "revisions.#", "3"),
+					resource.TestCheckResourceAttr(dataSourceName, "revisions.2.version", "0003"),
+					resource.TestCheckResourceAttr(dataSourceName, "revisions.2.delete_time", "2025-04-01T00:00:00Z"),
+				),
+			},
+			// filter by release
+			{
+				Config: getConfig(`
+	release      = "projects/mock-history/releases/r1"
+	show_deleted = true
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "revisions.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "revisions.0.version", "0001"),
+					resource.TestCheckResourceAttr(dataSourceName, "revisions.1.version", "0003"),
+				),
+			},
+			// filter by the create time range
+			{
+				Config: getConfig(`
+	show_deleted       = true
+	create_time_after  = "2025-01-15T00:00:00Z"
+	create_time_before = "2025-02-15T00:00:00Z"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "revisions.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "revisions.0.version", "0002"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,291 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func dataSourceEffectivePolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "The effective policy data source. It resolves the rollout or query data policy for the resource the same way as the Bytebase server. The rollout policy only applies to the environment, so it comes from the environment policy, or the policy of the instance or database environment. The query data policy combines the project and workspace policies and the most restrictive value wins: the smallest positive maximum_result_rows, disable_export or disable_copy_data if any policy disables it, and allow_admin_data_source only if every policy allows it. The inherit_from_parent is not used in the resolution.",
+		ReadContext: dataSourceEffectivePolicyRead,
+		Schema: map[string]*schema.Schema{
+			"parent": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateDiagFunc: internal.ResourceNameValidation(
+					// allow empty to default to workspace
+					"^$",
+					// workspace
+					fmt.Sprintf("^%s%s$", internal.WorkspaceNamePrefix, internal.ResourceIDPattern),
+					// environment
+					fmt.Sprintf("^%s%s$", internal.EnvironmentNamePrefix, internal.ResourceIDPattern),
+					// instance
+					fmt.Sprintf("^%s%s$", internal.InstanceNamePrefix, internal.ResourceIDPattern),
+					// project
+					fmt.Sprintf("^%s%s$", internal.ProjectNamePrefix, internal.ResourceIDPattern),
+					// database
+					fmt.Sprintf(`^%s%s/%s\S+$`, internal.InstanceNamePrefix, internal.ResourceIDPattern, internal.DatabaseIDPrefix),
+				),
+				Description: "The resource name to resolve the policy for, support workspaces/{workspace id}, projects/{resource id}, environments/{resource id}, instances/{resource id}, or instances/{resource id}/databases/{database name}. Defaults to the workspace if not specified.",
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					v1pb.PolicyType_ROLLOUT_POLICY.String(),
+					v1pb.PolicyType_DATA_QUERY.String(),
+				}, false),
+				Description: "The policy type, support ROLLOUT_POLICY and DATA_QUERY.",
+			},
+			"policies": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The policies used in the resolution, from the most specific to the least specific.",
+			},
+			"sources": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The policy full name that decides each value, keyed by the field name in the rollout_policy or query_data_policy. The value not in the map is not decided by any policy and uses the default.",
+			},
+			"rollout_policy":    getRolloutPolicySchema(true),
+			"query_data_policy": getDataQueryPolicySchema(true),
+		},
+	}
+}
+
+// effectivePolicyField is the policy field to resolve.
+type effectivePolicyField struct {
+	// key is the field name in the policy schema.
+	key string
+	// merge merges the field from the source policy into the effective policy.
+	// The first is true for the first policy in the resolution.
+	// It returns true if the source policy decides the effective value.
+	merge func(effective, source *v1pb.Policy, first bool) bool
+}
+
+// effectivePolicyFields is the resolvable fields for each policy type.
+// The rollout policy only applies to the environment, so its fields come from the single environment policy.
+// The query data policy combines the project and workspace policies, and the most restrictive value wins:
+// the smallest positive maximum_result_rows, any disable_export or disable_copy_data,
+// and allow_admin_data_source only if every policy allows it.
+var effectivePolicyFields = map[v1pb.PolicyType][]effectivePolicyField{
+	v1pb.PolicyType_ROLLOUT_POLICY: {
+		{
+			key: "automatic",
+			merge: func(effective, source *v1pb.Policy, first bool) bool {
+				if !first {
+					return false
+				}
+				effective.GetRolloutPolicy().Automatic = source.GetRolloutPolicy().GetAutomatic()
+				return true
+			},
+		},
+		{
+			key: "roles",
+			merge: func(effective, source *v1pb.Policy, first bool) bool {
+				if !first {
+					return false
+				}
+				effective.GetRolloutPolicy().Roles = source.GetRolloutPolicy().GetRoles()
+				return true
+			},
+		},
+	},
+	v1pb.PolicyType_DATA_QUERY: {
+		{
+			key: "maximum_result_rows",
+			merge: func(effective, source *v1pb.Policy, _ bool) bool {
+				// The value <= 0 means no limit.
+				rows, current := source.GetQueryDataPolicy().GetMaximumResultRows(), effective.GetQueryDataPolicy().GetMaximumResultRows()
+				if rows <= 0 || (current > 0 && current <= rows) {
+					return false
+				}
+				effective.GetQueryDataPolicy().MaximumResultRows = rows
+				return true
+			},
+		},
+		{
+			key: "disable_export",
+			merge: func(effective, source *v1pb.Policy, _ bool) bool {
+				if !source.GetQueryDataPolicy().GetDisableExport() || effective.GetQueryDataPolicy().GetDisableExport() {
+					return false
+				}
+				effective.GetQueryDataPolicy().DisableExport = true
+				return true
+			},
+		},
+		{
+			key: "disable_copy_data",
+			merge: func(effective, source *v1pb.Policy, _ bool) bool {
+				if !source.GetQueryDataPolicy().GetDisableCopyData() || effective.GetQueryDataPolicy().GetDisableCopyData() {
+					return false
+				}
+				effective.GetQueryDataPolicy().DisableCopyData = true
+				return true
+			},
+		},
+		{
+			key: "allow_admin_data_source",
+			merge: func(effective, source *v1pb.Policy, first bool) bool {
+				allow := source.GetQueryDataPolicy().GetAllowAdminDataSource()
+				if !first && (allow || !effective.GetQueryDataPolicy().GetAllowAdminDataSource()) {
+					return false
+				}
+				effective.GetQueryDataPolicy().AllowAdminDataSource = allow
+				return true
+			},
+		},
+	},
+}
+
+// getPolicyParentChain returns the policy parents that apply to the resource for the policy type, from the most specific to the least specific.
+// The rollout policy only applies to the environment of the resource.
+// The query data policy applies to the project of the resource and the workspace.
+func getPolicyParentChain(ctx context.Context, c api.Client, parent string, policyType v1pb.PolicyType) ([]string, error) {
+	workspace := c.GetWorkspaceName()
+	isDatabase := strings.HasPrefix(parent, internal.InstanceNamePrefix) && strings.Contains(parent, fmt.Sprintf("/%s", internal.DatabaseIDPrefix))
+
+	switch policyType {
+	case v1pb.PolicyType_ROLLOUT_POLICY:
+		switch {
+		case strings.HasPrefix(parent, internal.EnvironmentNamePrefix):
+			return []string{parent}, nil
+		case isDatabase:
+			database, err := c.GetDatabase(ctx, parent)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get database %s", parent)
+			}
+			if environment := database.GetEffectiveEnvironment(); environment != "" {
+				return []string{environment}, nil
+			}
+			return []string{}, nil
+		case strings.HasPrefix(parent, internal.InstanceNamePrefix):
+			instance, err := c.GetInstance(ctx, parent)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get instance %s", parent)
+			}
+			if environment := instance.GetEnvironment(); environment != "" {
+				return []string{environment}, nil
+			}
+			return []string{}, nil
+		default:
+			return nil, errors.Errorf("the %s only applies to the environment, use the environment, instance or database instead of %s", policyType.String(), parent)
+		}
+	case v1pb.PolicyType_DATA_QUERY:
+		switch {
+		case strings.HasPrefix(parent, internal.ProjectNamePrefix):
+			return []string{parent, workspace}, nil
+		case isDatabase:
+			database, err := c.GetDatabase(ctx, parent)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get database %s", parent)
+			}
+			if database.Project != "" {
+				return []string{database.Project, workspace}, nil
+			}
+			return []string{workspace}, nil
+		case parent == workspace, strings.HasPrefix(parent, internal.EnvironmentNamePrefix), strings.HasPrefix(parent, internal.InstanceNamePrefix):
+			return []string{workspace}, nil
+		default:
+			return nil, errors.Errorf("unsupported resource %s", parent)
+		}
+	default:
+		return nil, errors.Errorf("unsupported policy type %s", policyType.String())
+	}
+}
+
+// resolveEffectivePolicy merges the policies that apply to the resource, from the most specific to the least specific.
+// It returns the effective policy, the policies used in the resolution and the source policy that decides each field.
+func resolveEffectivePolicy(policyType v1pb.PolicyType, policies []*v1pb.Policy) (*v1pb.Policy, []string, map[string]string, error) {
+	effective := &v1pb.Policy{Type: policyType}
+	switch policyType {
+	case v1pb.PolicyType_ROLLOUT_POLICY:
+		effective.Policy = &v1pb.Policy_RolloutPolicy{RolloutPolicy: &v1pb.RolloutPolicy{}}
+	case v1pb.PolicyType_DATA_QUERY:
+		effective.Policy = &v1pb.Policy_QueryDataPolicy{QueryDataPolicy: &v1pb.QueryDataPolicy{}}
+	default:
+		return nil, nil, nil, errors.Errorf("unsupported policy type %s", policyType.String())
+	}
+
+	used := []string{}
+	sources := map[string]string{}
+	for i, policy := range policies {
+		used = append(used, policy.Name)
+		for _, field := range effectivePolicyFields[policyType] {
+			if field.merge(effective, policy, i == 0) {
+				sources[field.key] = policy.Name
+			}
+		}
+	}
+	return effective, used, sources, nil
+}
+
+func dataSourceEffectivePolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+
+	parent := internal.ResolveWorkspaceParent(d.Get("parent").(string), c.GetWorkspaceName())
+	policyType := v1pb.PolicyType(v1pb.PolicyType_value[d.Get("type").(string)])
+
+	chain, err := getPolicyParentChain(ctx, c, parent, policyType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policies := []*v1pb.Policy{}
+	for _, policyParent := range chain {
+		policyName := fmt.Sprintf("%s/%s%s", policyParent, internal.PolicyNamePrefix, policyType.String())
+		policy, err := c.GetPolicy(ctx, policyName)
+		if err != nil {
+			if internal.IsNotFoundError(err) {
+				continue
+			}
+			return diag.Errorf("failed to get policy %s with error: %s", policyName, err.Error())
+		}
+		policies = append(policies, policy)
+	}
+
+	effective, used, sources, err := resolveEffectivePolicy(policyType, policies)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/effectivePolicies/%s", parent, policyType.String()))
+	if err := d.Set("parent", parent); err != nil {
+		return diag.Errorf("cannot set parent for effective policy: %s", err.Error())
+	}
+	if err := d.Set("policies", used); err != nil {
+		return diag.Errorf("cannot set policies for effective policy: %s", err.Error())
+	}
+	if err := d.Set("sources", sources); err != nil {
+		return diag.Errorf("cannot set sources for effective policy: %s", err.Error())
+	}
+
+	switch policyType {
+	case v1pb.PolicyType_ROLLOUT_POLICY:
+		if err := d.Set("rollout_policy", flattenRolloutPolicy(effective.GetRolloutPolicy())); err != nil {
+			return diag.Errorf("cannot set rollout_policy for effective policy: %s", err.Error())
+		}
+	case v1pb.PolicyType_DATA_QUERY:
+		if err := d.Set("query_data_policy", flattenQueryDataPolicy(effective.GetQueryDataPolicy())); err != nil {
+			return diag.Errorf("cannot set query_data_policy for effective policy: %s", err.Error())
+		}
+	default:
+		// The type is validated by the schema.
+	}
+	return nil
+}
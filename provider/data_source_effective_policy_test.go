@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func TestAccEffectivePolicyDataSource(t *testing.T) {
+	workspacePolicy := fmt.Sprintf("%s%s/policies/DATA_QUERY", internal.WorkspaceNamePrefix, internal.MockWorkspaceID)
+	projectPolicy := "projects/project-sample/policies/DATA_QUERY"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckEffectivePolicyDataSource(true, 1000),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists("data.bytebase_effective_policy.query_data"),
+					resource.TestCheckResourceAttr("data.bytebase_effective_policy.query_data", "policies.#", "2"),
+					resource.TestCheckResourceAttr("data.bytebase_effective_policy.query_data", "policies.0", projectPolicy),
+					resource.TestCheckResourceAttr("data.bytebase_effective_policy.query_data", "policies.1", workspacePolicy),
+					resource.TestCheckResourceAttr("data.bytebase_effective_policy.query_data", "query_data_policy.0.maximum_result_rows", "500"),
+					resource.TestCheckResourceAttr("data.bytebase_effective_policy.query_data", "query_data_policy.0.disable_export", "true"),
+					resource.TestCheckResourceAttr("data.bytebase_effective_policy.query_data", "query_data_policy.0.disable_copy_data", "true"),
+					resource.TestCheckResourceAttr("data.bytebase_effective_policy.query_data", "query_data_policy.0.allow_admin_data_source", "false"),
+					resource.TestCheckResourceAttr("data.bytebase_effective_policy.query_data", "sources.maximum_result_rows", workspacePolicy),
+					resource.TestCheckResourceAttr("data.bytebase_effective_policy.query_data", "sources.disable_export", workspacePolicy),
+					resource.TestCheckResourceAttr("data.bytebase_effective_policy.query_data", "sources.disable_copy_data", projectPolicy),
+					resource.TestCheckResourceAttr("data.bytebase_effective_policy.query_data", "sources.allow_admin_data_source", projectPolicy),
+				),
+			},
+			// the most restrictive value wins regardless of the inherit_from_parent
+			{
+				Config: testAccCheckEffectivePolicyDataSource(false, 100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bytebase_effective_policy.query_data", "policies.#", "2"),
+					resource.TestCheckResourceAttr("data.bytebase_effective_policy.query_data", "query_data_policy.0.maximum_result_rows", "100"),
+					resource.TestCheckResourceAttr("data.bytebase_effective_policy.query_data", "query_data_policy.0.disable_export", "true"),
+					resource.TestCheckResourceAttr("data.bytebase_effective_policy.query_data", "sources.maximum_result_rows", projectPolicy),
+					resource.TestCheckResourceAttr("data.bytebase_effective_policy.query_data", "sources.disable_export", workspacePolicy),
+				),
+			},
+			// the rollout policy only applies to the environment
+			{
+				Config: `
+data "bytebase_effective_policy" "rollout" {
+	parent = "projects/project-sample"
+	type   = "ROLLOUT_POLICY"
+}
+`,
+				ExpectError: regexp.MustCompile("the ROLLOUT_POLICY only applies to the environment"),
+			},
+		},
+	})
+}
+
+func testAccCheckEffectivePolicyDataSource(inheritFromParent bool, maxResultRows int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "bytebase_policy" "project_query_data" {
+	parent              = "projects/project-sample"
+	type                = "DATA_QUERY"
+	inherit_from_parent = %t
+
+	query_data_policy {
+		disable_copy_data   = true
+		maximum_result_rows = %d
+	}
+}
+
+data "bytebase_effective_policy" "query_data" {
+	parent     = "projects/project-sample"
+	type       = "DATA_QUERY"
+	depends_on = [bytebase_policy.query_data_policy, bytebase_policy.project_query_data]
+}
+`, testAccCheckPolicyResource(
+		"query_data_policy",
+		fmt.Sprintf("%s%s", internal.WorkspaceNamePrefix, internal.MockWorkspaceID),
+		getQueryDataPolicy(true, 500),
+		v1pb.PolicyType_DATA_QUERY,
+	), inheritFromParent, maxResultRows)
+}
+
+func TestResolveEffectivePolicy(t *testing.T) {
+	environmentPolicy := &v1pb.Policy{
+		Name: "environments/prod/policies/ROLLOUT_POLICY",
+		Policy: &v1pb.Policy_RolloutPolicy{
+			RolloutPolicy: &v1pb.RolloutPolicy{
+				Roles: []string{"roles/projectOwner"},
+			},
+		},
+	}
+	effective, used, sources, err := resolveEffectivePolicy(v1pb.PolicyType_ROLLOUT_POLICY, []*v1pb.Policy{environmentPolicy})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if effective.GetRolloutPolicy().GetAutomatic() {
+		t.Errorf("automatic = true, want false")
+	}
+	if got := effective.GetRolloutPolicy().GetRoles(); !slices.Equal(got, []string{"roles/projectOwner"}) {
+		t.Errorf("roles = %v, want [roles/projectOwner]", got)
+	}
+	if want := []string{environmentPolicy.Name}; !slices.Equal(used, want) {
+		t.Errorf("policies = %v, want %v", used, want)
+	}
+	// the false and empty values in the environment policy still decide the rollout policy
+	if want := map[string]string{"automatic": environmentPolicy.Name, "roles": environmentPolicy.Name}; !maps.Equal(sources, want) {
+		t.Errorf("sources = %v, want %v", sources, want)
+	}
+
+	projectPolicy := &v1pb.Policy{
+		Name: "projects/p/policies/DATA_QUERY",
+		Policy: &v1pb.Policy_QueryDataPolicy{
+			QueryDataPolicy: &v1pb.QueryDataPolicy{
+				MaximumResultRows:    -1,
+				DisableCopyData:      true,
+				AllowAdminDataSource: true,
+			},
+		},
+	}
+	workspacePolicy := &v1pb.Policy{
+		Name: "workspaces/w/policies/DATA_QUERY",
+		Policy: &v1pb.Policy_QueryDataPolicy{
+			QueryDataPolicy: &v1pb.QueryDataPolicy{
+				MaximumResultRows: 200,
+				DisableExport:     true,
+			},
+		},
+	}
+	effective, _, sources, err = resolveEffectivePolicy(v1pb.PolicyType_DATA_QUERY, []*v1pb.Policy{projectPolicy, workspacePolicy})
+	if err != nil {
+		t.Fatal(err)
+	}
+	queryData := effective.GetQueryDataPolicy()
+	if queryData.GetMaximumResultRows() != 200 || !queryData.GetDisableExport() || !queryData.GetDisableCopyData() || queryData.GetAllowAdminDataSource() {
+		t.Errorf("query data policy = %v, want the most restrictive values", queryData)
+	}
+	if want := map[string]string{
+		"maximum_result_rows":     workspacePolicy.Name,
+		"disable_export":          workspacePolicy.Name,
+		"disable_copy_data":       projectPolicy.Name,
+		"allow_admin_data_source": workspacePolicy.Name,
+	}; !maps.Equal(sources, want) {
+		t.Errorf("sources = %v, want %v", sources, want)
+	}
+
+	if _, _, _, err := resolveEffectivePolicy(v1pb.PolicyType_TAG, nil); err == nil {
+		t.Errorf("resolveEffectivePolicy() expect error for the TAG policy")
+	}
+}
@@ -42,15 +42,10 @@ func dataSourcePolicy() *schema.Resource {
 				Description: "The policy parent name for the policy, support workspaces/{workspace id}, projects/{resource id}, environments/{resource id}, instances/{resource id}, or instances/{resource id}/databases/{database name}. Defaults to the workspace if not specified.",
 			},
 			"type": {
-				Type:     schema.TypeString,
-				Required: true,
-				ValidateFunc: validation.StringInSlice([]string{
-					v1pb.PolicyType_MASKING_EXEMPTION.String(),
-					v1pb.PolicyType_MASKING_RULE.String(),
-					v1pb.PolicyType_ROLLOUT_POLICY.String(),
-					v1pb.PolicyType_DATA_QUERY.String(),
-				}, false),
-				Description: "The policy type.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: getPolicyTypeValidation(),
+				Description:  "The policy type.",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -71,10 +66,30 @@ func dataSourcePolicy() *schema.Resource {
 			"global_masking_policy":    getGlobalMaskingPolicySchema(true),
 			"rollout_policy":           getRolloutPolicySchema(true),
 			"query_data_policy":        getDataQueryPolicySchema(true),
+			"tag_policy":               getTagPolicySchema(true),
 		},
 	}
 }
 
+// supportedPolicyTypes is the policy types modeled with the typed blocks.
+// It covers every policy type in the v1 API except the POLICY_TYPE_UNSPECIFIED,
+// the policy list skips the type added by a newer server until it's modeled here.
+var supportedPolicyTypes = []v1pb.PolicyType{
+	v1pb.PolicyType_MASKING_EXEMPTION,
+	v1pb.PolicyType_MASKING_RULE,
+	v1pb.PolicyType_ROLLOUT_POLICY,
+	v1pb.PolicyType_DATA_QUERY,
+	v1pb.PolicyType_TAG,
+}
+
+func getPolicyTypeValidation() schema.SchemaValidateFunc {
+	policyTypes := []string{}
+	for _, policyType := range supportedPolicyTypes {
+		policyTypes = append(policyTypes, policyType.String())
+	}
+	return validation.StringInSlice(policyTypes, false)
+}
+
 func getMaskingExemptionPolicySchema(computed bool) *schema.Schema {
 	return &schema.Schema{
 		Computed: computed,
@@ -284,6 +299,31 @@ func getRolloutPolicySchema(computed bool) *schema.Schema {
 	}
 }
 
+func getTagPolicySchema(computed bool) *schema.Schema {
+	return &schema.Schema{
+		Computed:    computed,
+		Optional:    true,
+		Default:     nil,
+		Type:        schema.TypeList,
+		MinItems:    0,
+		MaxItems:    1,
+		Description: "The tags for the project or environment. The bb.tag.review_config tag is also managed by the bytebase_review_config resources, avoid managing it in both places.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"tags": {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Computed:    computed,
+					Description: "The tags in key-value format, for example bb.tag.review_config = reviewConfigs/{id}.",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
 func dataSourcePolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(api.Client)
 
@@ -353,6 +393,10 @@ func flattenPolicyPayload(policy *v1pb.Policy) (string, interface{}, diag.Diagno
 			rolloutPolicy := flattenQueryDataPolicy(p)
 			return "query_data_policy", rolloutPolicy, nil
 		}
+	case v1pb.PolicyType_TAG:
+		if p := policy.GetTagPolicy(); p != nil {
+			return "tag_policy", flattenTagPolicy(p), nil
+		}
 	default:
 		// Unsupported policy type; fall through to the error below.
 	}
@@ -380,6 +424,17 @@ func flattenQueryDataPolicy(p *v1pb.QueryDataPolicy) []interface{} {
 	return []interface{}{policy}
 }
 
+func flattenTagPolicy(p *v1pb.TagPolicy) []interface{} {
+	tags := map[string]interface{}{}
+	for key, value := range p.Tags {
+		tags[key] = value
+	}
+	policy := map[string]interface{}{
+		"tags": tags,
+	}
+	return []interface{}{policy}
+}
+
 func flattenGlobalMaskingPolicy(p *v1pb.MaskingRulePolicy) ([]interface{}, error) {
 	ruleList := []interface{}{}
 
@@ -3,14 +3,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
-	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
-
 	"github.com/bytebase/terraform-provider-bytebase/api"
 	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
 )
@@ -69,6 +69,7 @@ func dataSourcePolicyList() *schema.Resource {
 						"global_masking_policy":    getGlobalMaskingPolicySchema(true),
 						"rollout_policy":           getRolloutPolicySchema(true),
 						"query_data_policy":        getDataQueryPolicySchema(true),
+						"tag_policy":               getTagPolicySchema(true),
 					},
 				},
 			},
@@ -88,7 +89,8 @@ func dataSourcePolicyListRead(ctx context.Context, d *schema.ResourceData, m int
 
 	policies := make([]map[string]interface{}, 0)
 	for _, policy := range response.Policies {
-		if policy.Type != v1pb.PolicyType_MASKING_EXEMPTION && policy.Type != v1pb.PolicyType_MASKING_RULE {
+		if !slices.Contains(supportedPolicyTypes, policy.Type) {
+			tflog.Debug(ctx, fmt.Sprintf("skip the policy %s with the unsupported type %s", policy.Name, policy.Type.String()))
 			continue
 		}
 		raw := make(map[string]interface{})
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -44,3 +45,57 @@ func TestAccPolicyListDataSource(t *testing.T) {
 		},
 	})
 }
+
+func TestAccPolicyListDataSource_PolicyTypes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+%s
+data "bytebase_policy_list" "project" {
+	parent     = "projects/project-sample"
+	depends_on = [bytebase_policy.query_data_policy, bytebase_policy.tag_policy]
+}
+`,
+					testAccCheckPolicyResource("query_data_policy", "projects/project-sample", getQueryDataPolicy(true, 500), v1pb.PolicyType_DATA_QUERY),
+					testAccCheckPolicyResource("tag_policy", "projects/project-sample", getTagPolicy("reviewConfigs/sql-review"), v1pb.PolicyType_TAG),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bytebase_policy_list.project", "policies.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.bytebase_policy_list.project", "policies.*", map[string]string{
+						"type":                               v1pb.PolicyType_DATA_QUERY.String(),
+						"query_data_policy.0.disable_export": "true",
+						"query_data_policy.0.maximum_result_rows": "500",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.bytebase_policy_list.project", "policies.*", map[string]string{
+						"type":                                   v1pb.PolicyType_TAG.String(),
+						"tag_policy.0.tags.bb.tag.review_config": "reviewConfigs/sql-review",
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+%s
+data "bytebase_policy_list" "environment" {
+	parent     = "environments/test"
+	depends_on = [bytebase_policy.rollout_policy]
+}
+`,
+					testAccCheckPolicyResource("rollout_policy", "environments/test", getRolloutPolicy(true, "roles/projectOwner"), v1pb.PolicyType_ROLLOUT_POLICY),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bytebase_policy_list.environment", "policies.#", "1"),
+					resource.TestCheckResourceAttr("data.bytebase_policy_list.environment", "policies.0.type", v1pb.PolicyType_ROLLOUT_POLICY.String()),
+					resource.TestCheckResourceAttr("data.bytebase_policy_list.environment", "policies.0.rollout_policy.0.automatic", "true"),
+					resource.TestCheckResourceAttr("data.bytebase_policy_list.environment", "policies.0.rollout_policy.0.roles.#", "1"),
+				),
+			},
+		},
+	})
+}
@@ -149,6 +149,8 @@ func testAccCheckProjectDataSourceWithSettings(identifier, resourceID, title str
 			environment = "test"
 			team        = "platform"
 		}
+
+		deletion_protection = false
 	}
 
 	data "bytebase_project" "%s" {
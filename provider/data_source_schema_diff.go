@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func dataSourceSchemaDiff() *schema.Resource {
+	return &schema.Resource{
+		Description:        "The schema diff data source. Compare the source schema with the target database using the Bytebase server-side diff, and return the migration DDL to converge the target database to the source.",
+		ReadWithoutTimeout: dataSourceSchemaDiffRead,
+		Schema: map[string]*schema.Schema{
+			"source_database": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"source_database", "source_schema"},
+				Description:  "The source database full name in instances/{instance}/databases/{database} format. The schema of the database is used as the desired schema.",
+				ValidateDiagFunc: internal.ResourceNameValidation(
+					// database name format
+					fmt.Sprintf(`^%s%s/%s\S+$`, internal.InstanceNamePrefix, internal.ResourceIDPattern, internal.DatabaseIDPrefix),
+				),
+			},
+			"source_schema": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"source_database", "source_schema"},
+				Description:  "The desired schema in DDL text.",
+			},
+			"target_database": getDatabaseNameFilterSchema(),
+			"diff": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The migration DDL to apply on the target database to converge it to the source schema. Empty if the schemas are in sync.",
+			},
+			"in_sync": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the target database schema is in sync with the source schema.",
+			},
+		},
+	}
+}
+
+func dataSourceSchemaDiffRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	targetDatabase := d.Get("target_database").(string)
+
+	sourceSchema := d.Get("source_schema").(string)
+	if sourceDatabase := d.Get("source_database").(string); sourceDatabase != "" {
+		if sourceDatabase == targetDatabase {
+			return diag.Errorf("source_database and target_database cannot be the same database %s", targetDatabase)
+		}
+		databaseSchema, err := c.GetDatabaseSchema(ctx, sourceDatabase)
+		if err != nil {
+			return diag.FromErr(errors.Wrapf(err, "failed to get schema for database %s", sourceDatabase))
+		}
+		sourceSchema = databaseSchema.Schema
+	}
+
+	response, err := c.DiffSchema(ctx, &v1pb.DiffSchemaRequest{
+		Name: targetDatabase,
+		Target: &v1pb.DiffSchemaRequest_Schema{
+			Schema: sourceSchema,
+		},
+	})
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "failed to diff schema for database %s", targetDatabase))
+	}
+
+	if err := d.Set("diff", response.Diff); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("in_sync", strings.TrimSpace(response.Diff) == ""); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// always refresh
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func TestAccSchemaDiffDataSource(t *testing.T) {
+	identifier := "schema_diff_instance"
+	instanceID := "schema-diff-instance"
+	instance := testAccCheckInstanceResource(identifier, instanceID, "schema diff instance", "POSTGRES", "environments/test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+
+data "bytebase_schema_diff" "drift" {
+	source_schema   = "CREATE TABLE t (id INT);"
+	target_database = "${bytebase_instance.%s.name}/databases/default"
+}
+`, instance, identifier),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists("data.bytebase_schema_diff.drift"),
+					resource.TestCheckResourceAttr("data.bytebase_schema_diff.drift", "in_sync", "false"),
+					resource.TestCheckResourceAttr("data.bytebase_schema_diff.drift", "diff", "CREATE TABLE t (id INT);"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+%s
+
+data "bytebase_schema_diff" "same" {
+	source_database = "${bytebase_instance.%s.name}/databases/test-database"
+	target_database = "${bytebase_instance.%s.name}/databases/default"
+}
+`, instance, identifier, identifier),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists("data.bytebase_schema_diff.same"),
+					resource.TestCheckResourceAttr("data.bytebase_schema_diff.same", "in_sync", "true"),
+					resource.TestCheckResourceAttr("data.bytebase_schema_diff.same", "diff", ""),
+				),
+			},
+		},
+	})
+}
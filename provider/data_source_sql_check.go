@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+)
+
+func dataSourceSQLCheck() *schema.Resource {
+	return &schema.Resource{
+		Description:        "The SQL check data source. Lint the statements against the SQL review rules for the target database, without executing them.",
+		ReadWithoutTimeout: dataSourceSQLCheckRead,
+		Schema: map[string]*schema.Schema{
+			"database": getDatabaseNameFilterSchema(),
+			"statements": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				AtLeastOneOf: []string{"statements", "files"},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				Description: "The SQL statements to check.",
+			},
+			"files": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				AtLeastOneOf: []string{"statements", "files"},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				Description: "The local SQL file paths to check.",
+			},
+			"change_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      v1pb.CheckRequest_DDL.String(),
+				ValidateFunc: validation.StringInSlice(getEnumNames(v1pb.CheckRequest_ChangeType_name, v1pb.CheckRequest_CHANGE_TYPE_UNSPECIFIED.String()), false),
+				Description:  "The change type of the statements, for example DDL or DML.",
+			},
+			"advices": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The advice source, in statements[{index}] format for statements or the file path for files.",
+						},
+						"rule_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The SQL review rule type which reports the advice.",
+						},
+						"level": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The advice level, can be SUCCESS, WARNING or ERROR.",
+						},
+						"code": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The advice code.",
+						},
+						"line": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The line number in the statement.",
+						},
+						"column": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The column number in the line.",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The advice message.",
+						},
+					},
+				},
+			},
+			"error_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The count of advices in ERROR level.",
+			},
+			"warning_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The count of advices in WARNING level.",
+			},
+			"passed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether all statements pass the check without errors.",
+			},
+		},
+	}
+}
+
+type sqlCheckSource struct {
+	source    string
+	statement string
+}
+
+func dataSourceSQLCheckRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	database := d.Get("database").(string)
+	changeType := v1pb.CheckRequest_ChangeType(v1pb.CheckRequest_ChangeType_value[d.Get("change_type").(string)])
+
+	sources := []*sqlCheckSource{}
+	for i, raw := range d.Get("statements").([]interface{}) {
+		sources = append(sources, &sqlCheckSource{
+			source:    fmt.Sprintf("statements[%d]", i),
+			statement: raw.(string),
+		})
+	}
+	for _, raw := range d.Get("files").([]interface{}) {
+		path := raw.(string)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return diag.FromErr(errors.Wrapf(err, "failed to read file %s", path))
+		}
+		sources = append(sources, &sqlCheckSource{
+			source:    path,
+			statement: string(content),
+		})
+	}
+
+	advices := []interface{}{}
+	errorCount, warningCount := 0, 0
+	for _, source := range sources {
+		response, err := c.CheckSQL(ctx, &v1pb.CheckRequest{
+			Name:       database,
+			Statement:  source.statement,
+			ChangeType: changeType,
+		})
+		if err != nil {
+			return diag.FromErr(errors.Wrapf(err, "failed to check %s", source.source))
+		}
+		for _, advice := range response.Advices {
+			switch advice.GetStatus() {
+			case v1pb.Advice_ERROR:
+				errorCount++
+			case v1pb.Advice_WARNING:
+				warningCount++
+			default:
+			}
+			advices = append(advices, map[string]interface{}{
+				"source":    source.source,
+				"rule_type": advice.GetTitle(),
+				"level":     advice.GetStatus().String(),
+				"code":      int(advice.GetCode()),
+				"line":      int(advice.GetStartPosition().GetLine()),
+				"column":    int(advice.GetStartPosition().GetColumn()),
+				"message":   advice.GetContent(),
+			})
+		}
+	}
+
+	if err := d.Set("advices", advices); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("error_count", errorCount); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("warning_count", warningCount); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("passed", errorCount == 0); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// always refresh
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func TestAccSQLCheckDataSource(t *testing.T) {
+	identifier := "sql_check_instance"
+	instanceID := "sql-check-instance"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+
+data "bytebase_sql_check" "lint" {
+	database   = "${bytebase_instance.%s.name}/databases/default"
+	statements = [
+		"CREATE TABLE t (id INT);",
+		"ALTER TABLE t ADD COLUMN name TEXT;\nDROP TABLE t;",
+	]
+}
+`, testAccCheckInstanceResource(identifier, instanceID, "sql check instance", "POSTGRES", "environments/test"), identifier),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists("data.bytebase_sql_check.lint"),
+					resource.TestCheckResourceAttr("data.bytebase_sql_check.lint", "advices.#", "1"),
+					resource.TestCheckResourceAttr("data.bytebase_sql_check.lint", "advices.0.source", "statements[1]"),
+					resource.TestCheckResourceAttr("data.bytebase_sql_check.lint", "advices.0.level", "WARNING"),
+					resource.TestCheckResourceAttr("data.bytebase_sql_check.lint", "advices.0.line", "2"),
+					resource.TestCheckResourceAttr("data.bytebase_sql_check.lint", "warning_count", "1"),
+					resource.TestCheckResourceAttr("data.bytebase_sql_check.lint", "error_count", "0"),
+					resource.TestCheckResourceAttr("data.bytebase_sql_check.lint", "passed", "true"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+)
+
+func dataSourceSQLQuery() *schema.Resource {
+	return &schema.Resource{
+		Description: "The SQL query data source. Run the read-only query through the Bytebase SQL query service, which honors the query data policy, data masking and the permissions of the service account.",
+		ReadContext: dataSourceSQLQueryRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(1 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"database": getDatabaseNameFilterSchema(),
+			"statement": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The read-only SQL statement to run.",
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntBetween(1, 10000),
+				Description:  "The maximum number of rows to return.",
+			},
+			"data_source_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The instance data source id to run the query. Default to use the read-only data source if exists.",
+			},
+			"columns": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The column names of the result.",
+			},
+			"column_types": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The column type names of the result.",
+			},
+			"rows": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{Type: schema.TypeString},
+				},
+				Description: "The result rows, each row is a map from the column name to the value in string. The NULL value is converted to an empty string, and the bytes value is base64 encoded.",
+			},
+			"row_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The count of returned rows.",
+			},
+		},
+	}
+}
+
+func dataSourceSQLQueryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	database := d.Get("database").(string)
+
+	response, err := c.QuerySQL(ctx, &v1pb.QueryRequest{
+		Name:         database,
+		Statement:    d.Get("statement").(string),
+		Limit:        int32(d.Get("limit").(int)),
+		DataSourceId: d.Get("data_source_id").(string),
+	})
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "failed to query database %s", database))
+	}
+	if len(response.Results) == 0 {
+		return diag.Errorf("no result returned for the query on database %s", database)
+	}
+
+	var diags diag.Diagnostics
+	if len(response.Results) > 1 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Multiple query results",
+			Detail:   "The statement returns multiple results, only the first result is used.",
+		})
+	}
+
+	result := response.Results[0]
+	if result.GetError() != "" {
+		return diag.Errorf("failed to query database %s: %s", database, result.GetError())
+	}
+
+	columns := result.GetColumnNames()
+	rows := []interface{}{}
+	for _, row := range result.GetRows() {
+		raw := map[string]interface{}{}
+		for i, value := range row.GetValues() {
+			if i >= len(columns) {
+				break
+			}
+			raw[columns[i]] = convertRowValueToString(value)
+		}
+		rows = append(rows, raw)
+	}
+
+	if err := d.Set("columns", columns); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("column_types", result.GetColumnTypeNames()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("rows", rows); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("row_count", len(rows)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// always refresh
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
+
+// convertRowValueToString converts the query row value to string, since the terraform map only supports a single value type.
+func convertRowValueToString(value *v1pb.RowValue) string {
+	if value == nil {
+		return ""
+	}
+	switch v := value.Kind.(type) {
+	case nil, *v1pb.RowValue_NullValue:
+		return ""
+	case *v1pb.RowValue_BoolValue:
+		return strconv.FormatBool(v.BoolValue)
+	case *v1pb.RowValue_BytesValue:
+		return base64.StdEncoding.EncodeToString(v.BytesValue)
+	case *v1pb.RowValue_DoubleValue:
+		return strconv.FormatFloat(v.DoubleValue, 'f', -1, 64)
+	case *v1pb.RowValue_FloatValue:
+		return strconv.FormatFloat(float64(v.FloatValue), 'f', -1, 32)
+	case *v1pb.RowValue_Int32Value:
+		return strconv.FormatInt(int64(v.Int32Value), 10)
+	case *v1pb.RowValue_Int64Value:
+		return strconv.FormatInt(v.Int64Value, 10)
+	case *v1pb.RowValue_Uint32Value:
+		return strconv.FormatUint(uint64(v.Uint32Value), 10)
+	case *v1pb.RowValue_Uint64Value:
+		return strconv.FormatUint(v.Uint64Value, 10)
+	case *v1pb.RowValue_StringValue:
+		return v.StringValue
+	default:
+		// Fallback to the JSON representation for the structured values, e.g. timestamp and JSON.
+		bytes, err := protojson.Marshal(value)
+		if err != nil {
+			return ""
+		}
+		return string(bytes)
+	}
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func TestAccSQLQueryDataSource(t *testing.T) {
+	identifier := "sql_query_instance"
+	instanceID := "sql-query-instance"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+
+data "bytebase_sql_query" "tenants" {
+	database  = "${bytebase_instance.%s.name}/databases/default"
+	statement = "SELECT id, name FROM tenant"
+}
+`, testAccCheckInstanceResource(identifier, instanceID, "sql query instance", "POSTGRES", "environments/test"), identifier),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists("data.bytebase_sql_query.tenants"),
+					resource.TestCheckResourceAttr("data.bytebase_sql_query.tenants", "columns.#", "2"),
+					resource.TestCheckResourceAttr("data.bytebase_sql_query.tenants", "columns.1", "name"),
+					resource.TestCheckResourceAttr("data.bytebase_sql_query.tenants", "row_count", "2"),
+					resource.TestCheckResourceAttr("data.bytebase_sql_query.tenants", "rows.0.id", "1"),
+					resource.TestCheckResourceAttr("data.bytebase_sql_query.tenants", "rows.0.name", "tenant-a"),
+					resource.TestCheckResourceAttr("data.bytebase_sql_query.tenants", "rows.1.name", ""),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+%s
+
+data "bytebase_sql_query" "tenants" {
+	database  = "${bytebase_instance.%s.name}/databases/default"
+	statement = "SELECT id, name FROM tenant"
+	limit     = 1
+}
+`, testAccCheckInstanceResource(identifier, instanceID, "sql query instance", "POSTGRES", "environments/test"), identifier),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bytebase_sql_query.tenants", "row_count", "1"),
+					resource.TestCheckResourceAttr("data.bytebase_sql_query.tenants", "rows.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestConvertRowValueToString(t *testing.T) {
+	tests := []struct {
+		name  string
+		value *v1pb.RowValue
+		want  string
+	}{
+		{name: "nil", value: nil, want: ""},
+		{name: "null", value: &v1pb.RowValue{Kind: &v1pb.RowValue_NullValue{}}, want: ""},
+		{name: "bool", value: &v1pb.RowValue{Kind: &v1pb.RowValue_BoolValue{BoolValue: true}}, want: "true"},
+		{name: "bytes", value: &v1pb.RowValue{Kind: &v1pb.RowValue_BytesValue{BytesValue: []byte("hi")}}, want: "aGk="},
+		{name: "double", value: &v1pb.RowValue{Kind: &v1pb.RowValue_DoubleValue{DoubleValue: 1.5}}, want: "1.5"},
+		{name: "int64", value: &v1pb.RowValue{Kind: &v1pb.RowValue_Int64Value{Int64Value: -42}}, want: "-42"},
+		{name: "uint64", value: &v1pb.RowValue{Kind: &v1pb.RowValue_Uint64Value{Uint64Value: 42}}, want: "42"},
+		{name: "string", value: &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: "tenant-a"}}, want: "tenant-a"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := convertRowValueToString(tc.value); got != tc.want {
+				t.Fatalf("convertRowValueToString() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	v1alpha1 "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+// errNoSuchKey is the CEL runtime error for the missing label, it's absorbed by the && and || logic as CEL does.
+var errNoSuchKey = errors.New("no such key")
+
+// databaseConditionResource is the database attributes used in the database group condition.
+type databaseConditionResource struct {
+	environmentID string
+	instanceID    string
+	databaseName  string
+	labels        map[string]string
+}
+
+// newDatabaseConditionResource returns the condition attributes for the database.
+func newDatabaseConditionResource(database *v1pb.Database) (*databaseConditionResource, error) {
+	instanceID, databaseName, err := internal.GetInstanceDatabaseID(database.Name)
+	if err != nil {
+		return nil, err
+	}
+	r := &databaseConditionResource{
+		instanceID:   instanceID,
+		databaseName: databaseName,
+		labels:       database.Labels,
+	}
+	if environment := database.GetEffectiveEnvironment(); environment != "" {
+		environmentID, err := internal.GetEnvironmentID(environment)
+		if err != nil {
+			return nil, err
+		}
+		r.environmentID = environmentID
+	}
+	return r, nil
+}
+
+// parseDatabaseCondition parses the database group condition with the Bytebase CEL parser.
+// The expression is checked against an empty database, so the unsupported expression fails before any database is matched.
+func parseDatabaseCondition(ctx context.Context, client api.Client, condition string) (*v1alpha1.Expr, error) {
+	expr, err := client.ParseExpression(ctx, condition)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid condition %q", condition)
+	}
+	if _, err := matchDatabaseCondition(expr, &databaseConditionResource{}); err != nil {
+		return nil, errors.Wrapf(err, "invalid condition %q", condition)
+	}
+	return expr, nil
+}
+
+// matchDatabaseCondition returns true if the database matches the parsed condition.
+// The database doesn't match if the condition evaluates to a CEL runtime error, such as the missing label.
+func matchDatabaseCondition(expr *v1alpha1.Expr, r *databaseConditionResource) (bool, error) {
+	value, err := evalDatabaseCondition(expr, r)
+	if errors.Is(err, errNoSuchKey) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	matched, ok := value.(bool)
+	if !ok {
+		return false, errors.New("the condition must be a bool expression")
+	}
+	return matched, nil
+}
+
+// conditionReferences returns true if the parsed condition uses the resource attribute.
+func conditionReferences(expr *v1alpha1.Expr, attribute string) bool {
+	switch kind := expr.GetExprKind().(type) {
+	case *v1alpha1.Expr_SelectExpr:
+		if kind.SelectExpr.GetField() == attribute {
+			return true
+		}
+		return conditionReferences(kind.SelectExpr.GetOperand(), attribute)
+	case *v1alpha1.Expr_CallExpr:
+		if target := kind.CallExpr.GetTarget(); target != nil && conditionReferences(target, attribute) {
+			return true
+		}
+		for _, arg := range kind.CallExpr.GetArgs() {
+			if conditionReferences(arg, attribute) {
+				return true
+			}
+		}
+	case *v1alpha1.Expr_ListExpr:
+		for _, element := range kind.ListExpr.GetElements() {
+			if conditionReferences(element, attribute) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// evalDatabaseCondition evaluates the expression to the bool, string, []string or map[string]string value.
+// It supports the resource.environment_id, resource.instance_id, resource.database_name and resource.database_labels["key"] attributes
+// with the ==, != and in operators, the startsWith, endsWith, contains and matches functions, and the &&, || and ! logic.
+// Both sides of the logic are always evaluated, so the unsupported expression fails regardless of the database.
+func evalDatabaseCondition(expr *v1alpha1.Expr, r *databaseConditionResource) (interface{}, error) {
+	switch kind := expr.GetExprKind().(type) {
+	case *v1alpha1.Expr_ConstExpr:
+		switch value := kind.ConstExpr.GetConstantKind().(type) {
+		case *v1alpha1.Constant_StringValue:
+			return value.StringValue, nil
+		case *v1alpha1.Constant_BoolValue:
+			return value.BoolValue, nil
+		default:
+			return nil, errors.Errorf("unsupported constant %v, only the string and bool are supported", kind.ConstExpr)
+		}
+	case *v1alpha1.Expr_IdentExpr:
+		return nil, errors.Errorf("unsupported identifier %q, only the resource attributes are supported", kind.IdentExpr.GetName())
+	case *v1alpha1.Expr_SelectExpr:
+		if kind.SelectExpr.GetOperand().GetIdentExpr().GetName() != "resource" {
+			return nil, errors.Errorf("unsupported attribute %q, only the resource attributes are supported", kind.SelectExpr.GetField())
+		}
+		switch kind.SelectExpr.GetField() {
+		case "environment_id":
+			return r.environmentID, nil
+		case "instance_id":
+			return r.instanceID, nil
+		case "database_name":
+			return r.databaseName, nil
+		case "database_labels":
+			if r.labels == nil {
+				return map[string]string{}, nil
+			}
+			return r.labels, nil
+		default:
+			return nil, errors.Errorf("unsupported attribute resource.%s", kind.SelectExpr.GetField())
+		}
+	case *v1alpha1.Expr_ListExpr:
+		values := []string{}
+		for _, element := range kind.ListExpr.GetElements() {
+			value, err := evalDatabaseCondition(element, r)
+			if err != nil {
+				return nil, err
+			}
+			s, ok := value.(string)
+			if !ok {
+				return nil, errors.Errorf("unsupported list element %v, only the string is supported", value)
+			}
+			values = append(values, s)
+		}
+		return values, nil
+	case *v1alpha1.Expr_CallExpr:
+		return evalDatabaseConditionCall(kind.CallExpr, r)
+	default:
+		return nil, errors.Errorf("unsupported expression %v", expr)
+	}
+}
+
+func evalDatabaseConditionCall(call *v1alpha1.Expr_Call, r *databaseConditionResource) (interface{}, error) {
+	args := []interface{}{}
+	argErrs := []error{}
+	for _, arg := range call.GetArgs() {
+		value, err := evalDatabaseCondition(arg, r)
+		if err != nil && !errors.Is(err, errNoSuchKey) {
+			return nil, err
+		}
+		args = append(args, value)
+		argErrs = append(argErrs, err)
+	}
+
+	switch call.GetFunction() {
+	case "_&&_", "_||_":
+		if len(args) != 2 {
+			return nil, errors.Errorf("invalid %s arguments", call.GetFunction())
+		}
+		// The false && error is false and the true || error is true, other errors are kept as CEL does.
+		absorb := call.GetFunction() == "_||_"
+		var absorbedErr error
+		for i, arg := range args {
+			if argErrs[i] != nil {
+				absorbedErr = argErrs[i]
+				continue
+			}
+			b, ok := arg.(bool)
+			if !ok {
+				return nil, errors.Errorf("the %s arguments must be bool", call.GetFunction())
+			}
+			if b == absorb {
+				return absorb, nil
+			}
+		}
+		if absorbedErr != nil {
+			return nil, absorbedErr
+		}
+		return !absorb, nil
+	}
+
+	for _, err := range argErrs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch call.GetFunction() {
+	case "!_":
+		if len(args) != 1 {
+			return nil, errors.New("invalid ! arguments")
+		}
+		b, ok := args[0].(bool)
+		if !ok {
+			return nil, errors.New("the ! argument must be bool")
+		}
+		return !b, nil
+	case "_==_", "_!=_":
+		if len(args) != 2 {
+			return nil, errors.Errorf("invalid %s arguments", call.GetFunction())
+		}
+		if !isDatabaseConditionScalar(args[0]) || !isDatabaseConditionScalar(args[1]) {
+			return nil, errors.Errorf("the %s arguments must be string or bool", call.GetFunction())
+		}
+		equal := args[0] == args[1]
+		if call.GetFunction() == "_==_" {
+			return equal, nil
+		}
+		return !equal, nil
+	case "@in":
+		if len(args) != 2 {
+			return nil, errors.New("invalid in arguments")
+		}
+		value, ok := args[0].(string)
+		if !ok {
+			return nil, errors.New("the in left argument must be string")
+		}
+		list, ok := args[1].([]string)
+		if !ok {
+			return nil, errors.New("the in right argument must be the string list")
+		}
+		for _, v := range list {
+			if v == value {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "_[_]":
+		if len(args) != 2 {
+			return nil, errors.New("invalid index arguments")
+		}
+		labels, ok := args[0].(map[string]string)
+		if !ok {
+			return nil, errors.New("only the resource.database_labels supports the index")
+		}
+		key, ok := args[1].(string)
+		if !ok {
+			return nil, errors.New("the label key must be string")
+		}
+		value, ok := labels[key]
+		if !ok {
+			return nil, errNoSuchKey
+		}
+		return value, nil
+	case "startsWith", "endsWith", "contains", "matches":
+		if call.GetTarget() == nil || len(args) != 1 {
+			return nil, errors.Errorf("invalid %s arguments", call.GetFunction())
+		}
+		target, err := evalDatabaseCondition(call.GetTarget(), r)
+		if err != nil {
+			return nil, err
+		}
+		s, ok := target.(string)
+		if !ok {
+			return nil, errors.Errorf("the %s target must be string", call.GetFunction())
+		}
+		arg, ok := args[0].(string)
+		if !ok {
+			return nil, errors.Errorf("the %s argument must be string", call.GetFunction())
+		}
+		switch call.GetFunction() {
+		case "startsWith":
+			return strings.HasPrefix(s, arg), nil
+		case "endsWith":
+			return strings.HasSuffix(s, arg), nil
+		case "contains":
+			return strings.Contains(s, arg), nil
+		default:
+			// CEL uses the RE2 syntax, the same as the Go regexp.
+			re, err := regexp.Compile(arg)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid matches pattern %q", arg)
+			}
+			return re.MatchString(s), nil
+		}
+	default:
+		return nil, errors.Errorf("unsupported function %q", strings.Trim(call.GetFunction(), "_@"))
+	}
+}
+
+func isDatabaseConditionScalar(value interface{}) bool {
+	switch value.(type) {
+	case string, bool:
+		return true
+	default:
+		return false
+	}
+}
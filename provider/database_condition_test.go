@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"testing"
+
+	v1alpha1 "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+)
+
+func testCallExpr(function string, target *v1alpha1.Expr, args ...*v1alpha1.Expr) *v1alpha1.Expr {
+	return &v1alpha1.Expr{ExprKind: &v1alpha1.Expr_CallExpr{CallExpr: &v1alpha1.Expr_Call{Function: function, Target: target, Args: args}}}
+}
+
+func testResourceExpr(field string) *v1alpha1.Expr {
+	return &v1alpha1.Expr{ExprKind: &v1alpha1.Expr_SelectExpr{SelectExpr: &v1alpha1.Expr_Select{
+		Operand: &v1alpha1.Expr{ExprKind: &v1alpha1.Expr_IdentExpr{IdentExpr: &v1alpha1.Expr_Ident{Name: "resource"}}},
+		Field:   field,
+	}}}
+}
+
+func testConstExpr(constant *v1alpha1.Constant) *v1alpha1.Expr {
+	return &v1alpha1.Expr{ExprKind: &v1alpha1.Expr_ConstExpr{ConstExpr: constant}}
+}
+
+func testStringExpr(value string) *v1alpha1.Expr {
+	return testConstExpr(&v1alpha1.Constant{ConstantKind: &v1alpha1.Constant_StringValue{StringValue: value}})
+}
+
+func testLabelExpr(key string) *v1alpha1.Expr {
+	return testCallExpr("_[_]", nil, testResourceExpr("database_labels"), testStringExpr(key))
+}
+
+func TestMatchDatabaseCondition(t *testing.T) {
+	environment := "environments/prod"
+	r, err := newDatabaseConditionResource(&v1pb.Database{
+		Name:                 "instances/mysql-1/databases/tenant_a",
+		EffectiveEnvironment: &environment,
+		Labels: map[string]string{
+			"tenant": "a",
+		},
+	})
+	if err != nil {
+		t.Fatalf("newDatabaseConditionResource returned error: %v", err)
+	}
+
+	isProd := testCallExpr("_==_", nil, testResourceExpr("environment_id"), testStringExpr("prod"))
+	missingLabel := testCallExpr("_==_", nil, testLabelExpr("missing"), testStringExpr(""))
+	tests := []struct {
+		name string
+		expr *v1alpha1.Expr
+		want bool
+	}{
+		{`resource.environment_id == "prod"`, isProd, true},
+		{`resource.environment_id != "prod"`, testCallExpr("_!=_", nil, testResourceExpr("environment_id"), testStringExpr("prod")), false},
+		{`resource.instance_id in ["mysql-1", "mysql-2"]`, testCallExpr("@in", nil, testResourceExpr("instance_id"), &v1alpha1.Expr{
+			ExprKind: &v1alpha1.Expr_ListExpr{ListExpr: &v1alpha1.Expr_CreateList{Elements: []*v1alpha1.Expr{testStringExpr("mysql-1"), testStringExpr("mysql-2")}}},
+		}), true},
+		{`resource.database_name.startsWith("tenant_")`, testCallExpr("startsWith", testResourceExpr("database_name"), testStringExpr("tenant_")), true},
+		{`resource.database_name.endsWith("_b")`, testCallExpr("endsWith", testResourceExpr("database_name"), testStringExpr("_b")), false},
+		{`resource.database_name.contains("ant")`, testCallExpr("contains", testResourceExpr("database_name"), testStringExpr("ant")), true},
+		{`resource.database_name.matches("^tenant_[a-z]$")`, testCallExpr("matches", testResourceExpr("database_name"), testStringExpr("^tenant_[a-z]$")), true},
+		{`resource.database_labels["tenant"] == "a"`, testCallExpr("_==_", nil, testLabelExpr("tenant"), testStringExpr("a")), true},
+		{`resource.database_labels["missing"] == ""`, missingLabel, false},
+		{`resource.database_labels["missing"] == "" || resource.environment_id == "prod"`, testCallExpr("_||_", nil, missingLabel, isProd), true},
+		{`resource.database_labels["missing"] == "" && !(resource.environment_id == "prod")`, testCallExpr("_&&_", nil, missingLabel, testCallExpr("!_", nil, isProd)), false},
+		{`true`, testConstExpr(&v1alpha1.Constant{ConstantKind: &v1alpha1.Constant_BoolValue{BoolValue: true}}), true},
+	}
+	for _, test := range tests {
+		got, err := matchDatabaseCondition(test.expr, r)
+		if err != nil {
+			t.Fatalf("matchDatabaseCondition(%s) returned error: %v", test.name, err)
+		}
+		if got != test.want {
+			t.Errorf("matchDatabaseCondition(%s) = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestMatchDatabaseConditionUnsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		expr *v1alpha1.Expr
+	}{
+		{`name.matches("tenant_")`, testCallExpr("matches", &v1alpha1.Expr{ExprKind: &v1alpha1.Expr_IdentExpr{IdentExpr: &v1alpha1.Expr_Ident{Name: "name"}}}, testStringExpr("tenant_"))},
+		{`resource.engine == "MYSQL"`, testCallExpr("_==_", nil, testResourceExpr("engine"), testStringExpr("MYSQL"))},
+		{`resource.database_name.size() > 3`, testCallExpr("_>_", nil, testCallExpr("size", testResourceExpr("database_name")), testConstExpr(&v1alpha1.Constant{ConstantKind: &v1alpha1.Constant_Int64Value{Int64Value: 3}}))},
+		{`resource.database_name.matches("[")`, testCallExpr("matches", testResourceExpr("database_name"), testStringExpr("["))},
+		{`resource.database_name`, testResourceExpr("database_name")},
+		// The unsupported expression fails even if the other side of the logic decides the result.
+		{`false && resource.engine == "MYSQL"`, testCallExpr("_&&_", nil,
+			testConstExpr(&v1alpha1.Constant{ConstantKind: &v1alpha1.Constant_BoolValue{BoolValue: false}}),
+			testCallExpr("_==_", nil, testResourceExpr("engine"), testStringExpr("MYSQL")),
+		)},
+	}
+	for _, test := range tests {
+		if _, err := matchDatabaseCondition(test.expr, &databaseConditionResource{}); err == nil {
+			t.Errorf("matchDatabaseCondition(%s) returned no error", test.name)
+		}
+	}
+}
+
+func TestConditionReferences(t *testing.T) {
+	expr := testCallExpr("_&&_", nil,
+		testCallExpr("startsWith", testResourceExpr("database_name"), testStringExpr("tenant_")),
+		testCallExpr("!_", nil, testCallExpr("_==_", nil, testResourceExpr("environment_id"), testStringExpr("prod"))),
+	)
+	if !conditionReferences(expr, "environment_id") {
+		t.Errorf("conditionReferences(environment_id) = false, want true")
+	}
+	if conditionReferences(expr, "instance_id") {
+		t.Errorf("conditionReferences(instance_id) = true, want false")
+	}
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+// getDeletionProtectionSchema returns the deletion_protection schema for the resource.
+// The replaceKeys are the ForceNew attributes checked by validateDeletionProtection.
+func getDeletionProtectionSchema(resourceType string, defaultValue bool, replaceKeys ...string) *schema.Schema {
+	description := fmt.Sprintf("Prevent the %s from being destroyed. Set it to false and apply before the destroy.", resourceType)
+	if len(replaceKeys) > 0 {
+		description = fmt.Sprintf("Prevent the %s from being destroyed or replaced by the %s change. Set it to false and apply before the destroy or the replacement.", resourceType, strings.Join(replaceKeys, ", "))
+	}
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     defaultValue,
+		Description: description,
+	}
+}
+
+// deletionProtectionHint is the suggestion to unblock the destroy or the replacement.
+const deletionProtectionHint = "set deletion_protection = false and apply it first"
+
+// checkDeletionProtection returns the error if the deletion protection is enabled in the state.
+func checkDeletionProtection(d *schema.ResourceData, resourceType string) diag.Diagnostics {
+	if !d.Get("deletion_protection").(bool) {
+		return nil
+	}
+	return diag.Errorf("cannot destroy the %s %s because deletion_protection is enabled, %s", resourceType, d.Id(), deletionProtectionHint)
+}
+
+// validateDeletionProtection returns the CustomizeDiffFunc to fail the plan that replaces the protected resource.
+// The replacement is detected by the change of the replaceKeys, which must be the ForceNew attributes.
+// The deletion_protection value in the state is used, so disabling it in the same plan does not bypass the check.
+func validateDeletionProtection(resourceType string, replaceKeys ...string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+		if diff.Id() == "" {
+			return nil
+		}
+		if protected, _ := diff.GetChange("deletion_protection"); !protected.(bool) {
+			return nil
+		}
+		for _, key := range replaceKeys {
+			if diff.HasChange(key) {
+				return errors.Errorf("cannot replace the %s %s for the %s change because deletion_protection is enabled, %s", resourceType, diff.Id(), key, deletionProtectionHint)
+			}
+		}
+		return nil
+	}
+}
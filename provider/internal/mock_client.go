@@ -3,11 +3,17 @@ package internal
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"connectrpc.com/connect"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/bytebase/terraform-provider-bytebase/api"
 
@@ -36,6 +42,11 @@ var (
 	groupMap            map[string]*v1pb.Group
 	reviewConfigMap     map[string]*v1pb.ReviewConfig
 	databaseGroupMap    map[string]*v1pb.DatabaseGroup
+	changelogMap        map[string][]*v1pb.Changelog
+	revisionMap         map[string][]*v1pb.Revision
+	issueMap            map[string]*v1pb.Issue
+	databaseSchemaMap   map[string]string
+	auditLogMap         map[string][]*v1pb.AuditLog
 	workspaceIAMPolicy  *v1pb.IamPolicy
 )
 
@@ -54,6 +65,13 @@ func init() {
 	groupMap = map[string]*v1pb.Group{}
 	reviewConfigMap = map[string]*v1pb.ReviewConfig{}
 	databaseGroupMap = map[string]*v1pb.DatabaseGroup{}
+	changelogMap = map[string][]*v1pb.Changelog{}
+	revisionMap = map[string][]*v1pb.Revision{}
+	issueMap = map[string]*v1pb.Issue{}
+	databaseSchemaMap = map[string]string{}
+	auditLogMap = map[string][]*v1pb.AuditLog{
+		fmt.Sprintf("%s%s", WorkspaceNamePrefix, MockWorkspaceID): newMockAuditLogs(fmt.Sprintf("%s%s", WorkspaceNamePrefix, MockWorkspaceID)),
+	}
 	workspaceIAMPolicy = &v1pb.IamPolicy{}
 
 	// Initialize environment setting with an empty list
@@ -72,6 +90,7 @@ func init() {
 type mockClient struct {
 	workspaceName       string
 	defaultProjectName  string
+	labelConfig         *api.LabelConfig
 	instanceMap         map[string]*v1pb.Instance
 	policyMap           map[string]*v1pb.Policy
 	projectMap          map[string]*v1pb.Project
@@ -86,6 +105,11 @@ type mockClient struct {
 	groupMap            map[string]*v1pb.Group
 	reviewConfigMap     map[string]*v1pb.ReviewConfig
 	databaseGroupMap    map[string]*v1pb.DatabaseGroup
+	changelogMap        map[string][]*v1pb.Changelog
+	revisionMap         map[string][]*v1pb.Revision
+	issueMap            map[string]*v1pb.Issue
+	databaseSchemaMap   map[string]string
+	auditLogMap         map[string][]*v1pb.AuditLog
 }
 
 // GetWorkspaceName returns the workspace resource name.
@@ -98,6 +122,11 @@ func (c *mockClient) GetDefaultProjectName() string {
 	return c.defaultProjectName
 }
 
+// GetLabelConfig returns the provider-level labels config.
+func (c *mockClient) GetLabelConfig() *api.LabelConfig {
+	return c.labelConfig
+}
+
 // newMockClient returns the new Bytebase API mock client.
 func newMockClient(_, _, _ string) (api.Client, error) {
 	mu.RLock()
@@ -105,6 +134,7 @@ func newMockClient(_, _, _ string) (api.Client, error) {
 	return &mockClient{
 		workspaceName:       fmt.Sprintf("%s%s", WorkspaceNamePrefix, MockWorkspaceID),
 		defaultProjectName:  fmt.Sprintf("%sdefault-%s", ProjectNamePrefix, MockWorkspaceID),
+		labelConfig:         &api.LabelConfig{},
 		instanceMap:         instanceMap,
 		policyMap:           policyMap,
 		projectMap:          projectMap,
@@ -119,6 +149,11 @@ func newMockClient(_, _, _ string) (api.Client, error) {
 		groupMap:            groupMap,
 		reviewConfigMap:     reviewConfigMap,
 		databaseGroupMap:    databaseGroupMap,
+		changelogMap:        changelogMap,
+		revisionMap:         revisionMap,
+		issueMap:            issueMap,
+		databaseSchemaMap:   databaseSchemaMap,
+		auditLogMap:         auditLogMap,
 	}, nil
 }
 
@@ -143,7 +178,7 @@ func (c *mockClient) GetInstance(_ context.Context, instanceName string) (*v1pb.
 	defer mu.RUnlock()
 	ins, ok := c.instanceMap[instanceName]
 	if !ok {
-		return nil, errors.Errorf("Cannot found instance %s", instanceName)
+		return nil, connect.NewError(connect.CodeNotFound, errors.Errorf("Cannot found instance %s", instanceName))
 	}
 
 	return ins, nil
@@ -175,8 +210,9 @@ func (c *mockClient) CreateInstance(_ context.Context, instanceID string, instan
 
 	// Create default database
 	defaultDb := &v1pb.Database{
-		Name:  fmt.Sprintf("%s/%sdefault", ins.Name, DatabaseIDPrefix),
-		State: v1pb.State_ACTIVE,
+		Name:                 fmt.Sprintf("%s/%sdefault", ins.Name, DatabaseIDPrefix),
+		State:                v1pb.State_ACTIVE,
+		EffectiveEnvironment: ins.Environment,
 		Labels: map[string]string{
 			"bb.environment": envID,
 		},
@@ -184,24 +220,27 @@ func (c *mockClient) CreateInstance(_ context.Context, instanceID string, instan
 
 	// Also create test databases that will be used in tests
 	testDb := &v1pb.Database{
-		Name:  fmt.Sprintf("%s/%stest-database", ins.Name, DatabaseIDPrefix),
-		State: v1pb.State_ACTIVE,
+		Name:                 fmt.Sprintf("%s/%stest-database", ins.Name, DatabaseIDPrefix),
+		State:                v1pb.State_ACTIVE,
+		EffectiveEnvironment: ins.Environment,
 		Labels: map[string]string{
 			"bb.environment": envID,
 		},
 	}
 
 	testDbLabels := &v1pb.Database{
-		Name:  fmt.Sprintf("%s/%stest-database-labels", ins.Name, DatabaseIDPrefix),
-		State: v1pb.State_ACTIVE,
+		Name:                 fmt.Sprintf("%s/%stest-database-labels", ins.Name, DatabaseIDPrefix),
+		State:                v1pb.State_ACTIVE,
+		EffectiveEnvironment: ins.Environment,
 		Labels: map[string]string{
 			"bb.environment": envID,
 		},
 	}
 
 	testDbObjSchema := &v1pb.Database{
-		Name:  fmt.Sprintf("%s/%stest-db-objschema", ins.Name, DatabaseIDPrefix),
-		State: v1pb.State_ACTIVE,
+		Name:                 fmt.Sprintf("%s/%stest-db-objschema", ins.Name, DatabaseIDPrefix),
+		State:                v1pb.State_ACTIVE,
+		EffectiveEnvironment: ins.Environment,
 		Labels: map[string]string{
 			"bb.environment": envID,
 		},
@@ -214,6 +253,7 @@ func (c *mockClient) CreateInstance(_ context.Context, instanceID string, instan
 	c.databaseMap[testDb.Name] = testDb
 	c.databaseMap[testDbLabels.Name] = testDbLabels
 	c.databaseMap[testDbObjSchema.Name] = testDbObjSchema
+	c.seedDatabaseHistory(testDb.Name)
 
 	// Also create empty catalogs for the databases
 	c.databaseCatalogMap[defaultDb.Name] = &v1pb.DatabaseCatalog{
@@ -260,17 +300,89 @@ func (c *mockClient) UpdateInstance(ctx context.Context, patch *v1pb.Instance, u
 	return ins, nil
 }
 
+// AddDataSource adds the data source to the instance.
+func (c *mockClient) AddDataSource(ctx context.Context, instanceName string, dataSource *v1pb.DataSource) (*v1pb.Instance, error) {
+	ins, err := c.GetInstance(ctx, instanceName)
+	if err != nil {
+		return nil, err
+	}
+	for _, ds := range ins.DataSources {
+		if ds.Id == dataSource.Id {
+			return nil, errors.Errorf("data source %s already exists in instance %s", dataSource.Id, instanceName)
+		}
+	}
+
+	ins.DataSources = append(ins.DataSources, dataSource)
+	mu.Lock()
+	c.instanceMap[ins.Name] = ins
+	mu.Unlock()
+	return ins, nil
+}
+
+// UpdateDataSource updates the data source in the instance.
+func (c *mockClient) UpdateDataSource(ctx context.Context, instanceName string, dataSource *v1pb.DataSource, updateMasks []string) (*v1pb.Instance, error) {
+	ins, err := c.GetInstance(ctx, instanceName)
+	if err != nil {
+		return nil, err
+	}
+	index := slices.IndexFunc(ins.DataSources, func(ds *v1pb.DataSource) bool {
+		return ds.Id == dataSource.Id
+	})
+	if index < 0 {
+		return nil, errors.Errorf("Cannot found data source %s in instance %s", dataSource.Id, instanceName)
+	}
+	if len(updateMasks) > 0 {
+		ins.DataSources[index] = dataSource
+	}
+
+	mu.Lock()
+	c.instanceMap[ins.Name] = ins
+	mu.Unlock()
+	return ins, nil
+}
+
+// RemoveDataSource removes the data source from the instance.
+func (c *mockClient) RemoveDataSource(ctx context.Context, instanceName string, dataSource *v1pb.DataSource) (*v1pb.Instance, error) {
+	ins, err := c.GetInstance(ctx, instanceName)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(ins.DataSources, func(ds *v1pb.DataSource) bool {
+		return ds.Id == dataSource.Id
+	}) {
+		return nil, errors.Errorf("Cannot found data source %s in instance %s", dataSource.Id, instanceName)
+	}
+	ins.DataSources = slices.DeleteFunc(ins.DataSources, func(ds *v1pb.DataSource) bool {
+		return ds.Id == dataSource.Id
+	})
+
+	mu.Lock()
+	c.instanceMap[ins.Name] = ins
+	mu.Unlock()
+	return ins, nil
+}
+
 // DeleteInstance deletes the instance.
-func (c *mockClient) DeleteInstance(ctx context.Context, instanceName string) error {
+func (c *mockClient) DeleteInstance(ctx context.Context, instanceName string, purge bool) error {
 	ins, err := c.GetInstance(ctx, instanceName)
 	if err != nil {
 		return err
 	}
 
-	ins.State = v1pb.State_DELETED
 	mu.Lock()
+	defer mu.Unlock()
+	if purge {
+		delete(c.instanceMap, ins.Name)
+		for name := range c.databaseMap {
+			if strings.HasPrefix(name, fmt.Sprintf("%s/", ins.Name)) {
+				delete(c.databaseMap, name)
+				delete(c.databaseCatalogMap, name)
+			}
+		}
+		return nil
+	}
+	ins.State = v1pb.State_DELETED
 	c.instanceMap[ins.Name] = ins
-	mu.Unlock()
 
 	return nil
 }
@@ -290,8 +402,38 @@ func (c *mockClient) UndeleteInstance(ctx context.Context, instanceName string)
 	return ins, nil
 }
 
+// ValidateInstance tests the data source connections of the instance without creating it.
+// The hosts in the reserved ".invalid" domain are treated as unreachable.
+func (*mockClient) ValidateInstance(_ context.Context, _ string, instance *v1pb.Instance) error {
+	for _, dataSource := range instance.DataSources {
+		if strings.HasSuffix(dataSource.Host, ".invalid") {
+			return errors.Errorf("invalid datasource %s, error: dial tcp: lookup %s: no such host", dataSource.Type.String(), dataSource.Host)
+		}
+	}
+	return nil
+}
+
+// ValidateDataSource tests the data source connection in the existing instance without changing it.
+// The hosts in the reserved ".invalid" domain are treated as unreachable.
+func (c *mockClient) ValidateDataSource(ctx context.Context, instanceName string, dataSource *v1pb.DataSource, _ []string) error {
+	if _, err := c.GetInstance(ctx, instanceName); err != nil {
+		return err
+	}
+	if strings.HasSuffix(dataSource.Host, ".invalid") {
+		return errors.Errorf("invalid datasource %s, error: dial tcp: lookup %s: no such host", dataSource.Type.String(), dataSource.Host)
+	}
+	return nil
+}
+
 // SyncInstanceSchema will trigger the schema sync for an instance.
-func (*mockClient) SyncInstanceSchema(_ context.Context, _ string) error {
+func (c *mockClient) SyncInstanceSchema(_ context.Context, instanceName string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	ins, ok := c.instanceMap[instanceName]
+	if !ok {
+		return errors.Errorf("Cannot found instance %s", instanceName)
+	}
+	ins.LastSyncTime = timestamppb.Now()
 	return nil
 }
 
@@ -317,7 +459,7 @@ func (c *mockClient) GetPolicy(_ context.Context, policyName string) (*v1pb.Poli
 	defer mu.RUnlock()
 	policy, ok := c.policyMap[policyName]
 	if !ok {
-		return nil, errors.Errorf("Cannot found policy %s", policyName)
+		return nil, connect.NewError(connect.CodeNotFound, errors.Errorf("Cannot found policy %s", policyName))
 	}
 
 	return policy, nil
@@ -376,6 +518,17 @@ func (c *mockClient) UpsertPolicy(_ context.Context, patch *v1pb.Policy, updateM
 				QueryDataPolicy: v,
 			}
 		}
+	case v1pb.PolicyType_ROLLOUT_POLICY:
+		if !existed {
+			if patch.GetRolloutPolicy() == nil {
+				return nil, errors.Errorf("payload is required to create the policy")
+			}
+		}
+		if v := patch.GetRolloutPolicy(); v != nil {
+			policy.Policy = &v1pb.Policy_RolloutPolicy{
+				RolloutPolicy: v,
+			}
+		}
 	case v1pb.PolicyType_TAG:
 		if !existed {
 			if patch.GetTagPolicy() == nil {
@@ -419,30 +572,53 @@ func (c *mockClient) GetDatabase(_ context.Context, databaseName string) (*v1pb.
 	defer mu.RUnlock()
 	db, ok := c.databaseMap[databaseName]
 	if !ok {
-		return nil, errors.Errorf("Cannot found database %s", databaseName)
+		return nil, connect.NewError(connect.CodeNotFound, errors.Errorf("Cannot found database %s", databaseName))
 	}
 
 	return db, nil
 }
 
 // ListDatabase list the databases.
-func (c *mockClient) ListDatabase(_ context.Context, instaceID string, filter *api.DatabaseFilter, _ bool) ([]*v1pb.Database, error) {
+func (c *mockClient) ListDatabase(_ context.Context, parent string, filter *api.DatabaseFilter, _ bool) ([]*v1pb.Database, error) {
 	mu.RLock()
 	defer mu.RUnlock()
-	projectID := "-"
-	if filter.Project != "" {
-		projectID = filter.Project
-	}
 	databases := make([]*v1pb.Database, 0)
 	for _, db := range c.databaseMap {
-		if projectID != "-" && fmt.Sprintf(`"%s"`, db.Project) != projectID {
+		switch {
+		case strings.HasPrefix(parent, ProjectNamePrefix):
+			if db.Project != parent {
+				continue
+			}
+		case strings.HasPrefix(parent, InstanceNamePrefix) && parent != fmt.Sprintf("%s-", InstanceNamePrefix):
+			if !strings.HasPrefix(db.Name, fmt.Sprintf("%s/", parent)) {
+				continue
+			}
+		default:
+		}
+		if filter.Project != "" && db.Project != filter.Project && fmt.Sprintf(`"%s"`, db.Project) != filter.Project {
 			continue
 		}
-		if instaceID != "-" && !strings.HasPrefix(db.Name, fmt.Sprintf("%s%s", InstanceNamePrefix, instaceID)) {
+		if filter.Instance != "" && !strings.HasPrefix(db.Name, fmt.Sprintf("%s/", filter.Instance)) {
+			continue
+		}
+		if filter.Query != "" && !strings.Contains(db.Name, filter.Query) {
+			continue
+		}
+		matched := true
+		for _, label := range filter.Labels {
+			if db.Labels[label.Key] != label.Value {
+				matched = false
+				break
+			}
+		}
+		if !matched {
 			continue
 		}
 		databases = append(databases, db)
 	}
+	slices.SortFunc(databases, func(a, b *v1pb.Database) int {
+		return strings.Compare(a.Name, b.Name)
+	})
 
 	return databases, nil
 }
@@ -459,6 +635,10 @@ func (c *mockClient) UpdateDatabase(ctx context.Context, patch *v1pb.Database, u
 	if slices.Contains(updateMasks, "labels") {
 		db.Labels = patch.Labels
 	}
+	if slices.Contains(updateMasks, "environment") {
+		db.Environment = patch.Environment
+		db.EffectiveEnvironment = patch.Environment
+	}
 	mu.Lock()
 	c.databaseMap[db.Name] = db
 	mu.Unlock()
@@ -489,7 +669,7 @@ func (c *mockClient) GetDatabaseCatalog(_ context.Context, databaseName string)
 	defer mu.RUnlock()
 	db, ok := c.databaseCatalogMap[databaseName]
 	if !ok {
-		return nil, errors.Errorf("Cannot found database catalog %s", databaseName)
+		return nil, connect.NewError(connect.CodeNotFound, errors.Errorf("Cannot found database catalog %s", databaseName))
 	}
 
 	return db, nil
@@ -506,13 +686,381 @@ func (c *mockClient) UpdateDatabaseCatalog(_ context.Context, patch *v1pb.Databa
 	return patch, nil
 }
 
+// CreateDatabase creates the database on the instance immediately.
+func (c *mockClient) CreateDatabase(_ context.Context, projectName string, config *v1pb.Plan_CreateDatabaseConfig) (*v1pb.Issue, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := c.instanceMap[config.Target]; !ok {
+		return nil, errors.Errorf("Cannot found instance %s", config.Target)
+	}
+	databaseName := fmt.Sprintf("%s/%s%s", config.Target, DatabaseIDPrefix, config.Database)
+	if _, ok := c.databaseMap[databaseName]; ok {
+		return nil, errors.Errorf("Database %s already exists", databaseName)
+	}
+	c.databaseMap[databaseName] = &v1pb.Database{
+		Name:    databaseName,
+		Project: projectName,
+		State:   v1pb.State_ACTIVE,
+	}
+	c.databaseCatalogMap[databaseName] = &v1pb.DatabaseCatalog{
+		Name: databaseName,
+	}
+	issue := newMockIssue(projectName, len(c.databaseMap))
+	issue.Title = api.GetCreateDatabaseIssueTitle(databaseName)
+	issue.Status = v1pb.IssueStatus_DONE
+	c.issueMap[issue.Name] = issue
+	return issue, nil
+}
+
+// newMockIssue returns the issue with the rollout for the statements run immediately.
+func newMockIssue(projectName string, id int) *v1pb.Issue {
+	return &v1pb.Issue{
+		Name:    fmt.Sprintf("%s/issues/%d", projectName, id),
+		Rollout: fmt.Sprintf("%s/rollouts/%d", projectName, id),
+	}
+}
+
+// GetRollout gets the rollout by name. The statements run immediately in the mock, so the tasks are done.
+func (*mockClient) GetRollout(_ context.Context, rolloutName string) (*v1pb.Rollout, error) {
+	return &v1pb.Rollout{
+		Name: rolloutName,
+		Stages: []*v1pb.Stage{
+			{
+				Name: fmt.Sprintf("%s/stages/1", rolloutName),
+				Tasks: []*v1pb.Task{
+					{
+						Name:   fmt.Sprintf("%s/stages/1/tasks/1", rolloutName),
+						Status: v1pb.Task_DONE,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// DropDatabase drops the database immediately.
+func (c *mockClient) DropDatabase(_ context.Context, projectName, databaseName, _ string) (*v1pb.Issue, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := c.databaseMap[databaseName]; !ok {
+		return nil, errors.Errorf("Cannot found database %s", databaseName)
+	}
+	delete(c.databaseMap, databaseName)
+	delete(c.databaseCatalogMap, databaseName)
+	return newMockIssue(projectName, len(c.databaseMap)+1), nil
+}
+
+var (
+	mockRoleStatementRegex   = regexp.MustCompile(`^(CREATE|ALTER|DROP) ROLE "((?:[^"]|"")+)"`)
+	mockUserStatementRegex   = regexp.MustCompile(`^(CREATE|ALTER|DROP) USER '((?:[^']|'')+)'@'((?:[^']|'')+)'`)
+	mockConnectionLimitRegex = regexp.MustCompile(`CONNECTION LIMIT (-?\d+)`)
+	mockValidUntilRegex      = regexp.MustCompile(`VALID UNTIL '((?:[^']|'')*)'`)
+)
+
+// ChangeDatabase runs the statement immediately. Only the role statements change the instance roles.
+func (c *mockClient) ChangeDatabase(_ context.Context, projectName, databaseName, _, statement string) (*v1pb.Issue, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := c.databaseMap[databaseName]; !ok {
+		return nil, errors.Errorf("Cannot found database %s", databaseName)
+	}
+	instanceID, _, err := GetInstanceDatabaseID(databaseName)
+	if err != nil {
+		return nil, err
+	}
+	instance, ok := c.instanceMap[fmt.Sprintf("%s%s", InstanceNamePrefix, instanceID)]
+	if !ok {
+		return nil, errors.Errorf("Cannot found instance %s", instanceID)
+	}
+
+	// The role statements are one per line. The MySQL user is synced in 'user'@'host' format.
+	for _, stmt := range strings.Split(statement, "\n") {
+		var roleName string
+		matches := mockRoleStatementRegex.FindStringSubmatch(strings.TrimSpace(stmt))
+		if len(matches) > 0 {
+			roleName = strings.ReplaceAll(matches[2], `""`, `"`)
+		} else if matches = mockUserStatementRegex.FindStringSubmatch(strings.TrimSpace(stmt)); len(matches) > 0 {
+			roleName = fmt.Sprintf("'%s'@'%s'", strings.ReplaceAll(matches[2], "''", "'"), strings.ReplaceAll(matches[3], "''", "'"))
+		} else {
+			continue
+		}
+		index := slices.IndexFunc(instance.Roles, func(role *v1pb.InstanceRole) bool {
+			return role.RoleName == roleName
+		})
+		switch matches[1] {
+		case "CREATE":
+			if index >= 0 {
+				return nil, errors.Errorf("role %s already exists", roleName)
+			}
+			instance.Roles = append(instance.Roles, &v1pb.InstanceRole{
+				Name:     fmt.Sprintf("%s/roles/%s", instance.Name, roleName),
+				RoleName: roleName,
+			})
+			index = len(instance.Roles) - 1
+		case "DROP":
+			if index < 0 {
+				return nil, errors.Errorf("Cannot found role %s", roleName)
+			}
+			instance.Roles = slices.Delete(instance.Roles, index, index+1)
+			continue
+		default:
+			if index < 0 {
+				return nil, errors.Errorf("Cannot found role %s", roleName)
+			}
+		}
+
+		role := instance.Roles[index]
+		if m := mockConnectionLimitRegex.FindStringSubmatch(stmt); len(m) > 0 {
+			limit, err := strconv.ParseInt(m[1], 10, 32)
+			if err != nil {
+				return nil, err
+			}
+			connectionLimit := int32(limit)
+			role.ConnectionLimit = &connectionLimit
+		}
+		if m := mockValidUntilRegex.FindStringSubmatch(stmt); len(m) > 0 {
+			validUntil := strings.ReplaceAll(m[1], "''", "'")
+			role.ValidUntil = &validUntil
+		}
+	}
+
+	return newMockIssue(projectName, len(c.databaseMap)+1), nil
+}
+
+// mockHistoryProjectName is the project for the seeded changelog and revision issues.
+const mockHistoryProjectName = "projects/mock-history"
+
+// seedDatabaseHistory seeds the changelogs, revisions and their issues for the database, the caller must hold the lock.
+func (c *mockClient) seedDatabaseHistory(databaseName string) {
+	newTime := func(month int) *timestamppb.Timestamp {
+		return timestamppb.New(time.Date(2025, time.Month(month), 1, 0, 0, 0, 0, time.UTC))
+	}
+	devIssue := &v1pb.Issue{
+		Name:    fmt.Sprintf("%s/issues/1", mockHistoryProjectName),
+		Creator: "users/dev@bytebase.com",
+	}
+	dbaIssue := &v1pb.Issue{
+		Name:    fmt.Sprintf("%s/issues/2", mockHistoryProjectName),
+		Creator: "users/dba@bytebase.com",
+	}
+	c.issueMap[devIssue.Name] = devIssue
+	c.issueMap[dbaIssue.Name] = dbaIssue
+
+	c.changelogMap[databaseName] = []*v1pb.Changelog{
+		{
+			Name:          fmt.Sprintf("%s/changelogs/1", databaseName),
+			Version:       "0001",
+			Type:          v1pb.Changelog_MIGRATE,
+			Status:        v1pb.Changelog_DONE,
+			Statement:     "CREATE TABLE t1 (id INT);",
+			StatementSize: int64(len("CREATE TABLE t1 (id INT);")),
+			Issue:         devIssue.Name,
+			CreateTime:    newTime(1),
+		},
+		{
+			Name:          fmt.Sprintf("%s/changelogs/2", databaseName),
+			Version:       "0002",
+			Type:          v1pb.Changelog_SDL,
+			Status:        v1pb.Changelog_DONE,
+			Statement:     "CREATE TABLE t2 (id INT);",
+			StatementSize: int64(len("CREATE TABLE t2 (id INT);")),
+			Issue:         dbaIssue.Name,
+			CreateTime:    newTime(2),
+		},
+		{
+			Name:       fmt.Sprintf("%s/changelogs/3", databaseName),
+			Version:    "0003",
+			Type:       v1pb.Changelog_MIGRATE,
+			Status:     v1pb.Changelog_FAILED,
+			CreateTime: newTime(3),
+		},
+	}
+	c.revisionMap[databaseName] = []*v1pb.Revision{
+		{
+			Name:       fmt.Sprintf("%s/revisions/1", databaseName),
+			Version:    "0001",
+			Release:    fmt.Sprintf("%s/releases/r1", mockHistoryProjectName),
+			Statement:  "CREATE TABLE t1 (id INT);",
+			Issue:      devIssue.Name,
+			Creator:    devIssue.Creator,
+			CreateTime: newTime(1),
+		},
+		{
+			Name:       fmt.Sprintf("%s/revisions/2", databaseName),
+			Version:    "0002",
+			Release:    fmt.Sprintf("%s/releases/r2", mockHistoryProjectName),
+			Statement:  "CREATE TABLE t2 (id INT);",
+			Issue:      dbaIssue.Name,
+			Creator:    dbaIssue.Creator,
+			CreateTime: newTime(2),
+		},
+		{
+			Name:       fmt.Sprintf("%s/revisions/3", databaseName),
+			Version:    "0003",
+			Release:    fmt.Sprintf("%s/releases/r1", mockHistoryProjectName),
+			Creator:    devIssue.Creator,
+			CreateTime: newTime(3),
+			DeleteTime: newTime(4),
+		},
+	}
+}
+
+// inMockTimeRange checks if the timestamp is in the [after, before] range, the zero time means no bound.
+func inMockTimeRange(ts *timestamppb.Timestamp, after, before time.Time) bool {
+	if after.IsZero() && before.IsZero() {
+		return true
+	}
+	if ts == nil {
+		return false
+	}
+	t := ts.AsTime()
+	return (after.IsZero() || !t.Before(after)) && (before.IsZero() || !t.After(before))
+}
+
+// ListChangelogs lists the changelogs of the database.
+func (c *mockClient) ListChangelogs(_ context.Context, databaseName string, filter *api.ChangelogFilter) ([]*v1pb.Changelog, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	changelogs := make([]*v1pb.Changelog, 0)
+	for _, changelog := range c.changelogMap[databaseName] {
+		if len(filter.Types) > 0 && !slices.Contains(filter.Types, changelog.Type) {
+			continue
+		}
+		if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, changelog.Status) {
+			continue
+		}
+		if !inMockTimeRange(changelog.CreateTime, filter.CreateAfter, filter.CreateBefore) {
+			continue
+		}
+		if !filter.FullStatement {
+			// The basic view doesn't return the statement.
+			changelog = proto.Clone(changelog).(*v1pb.Changelog)
+			changelog.Statement = ""
+		}
+		changelogs = append(changelogs, changelog)
+	}
+	return changelogs, nil
+}
+
+// ListRevisions lists the revisions of the database.
+func (c *mockClient) ListRevisions(_ context.Context, databaseName string, filter *api.RevisionFilter) ([]*v1pb.Revision, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	revisions := make([]*v1pb.Revision, 0)
+	for _, revision := range c.revisionMap[databaseName] {
+		if filter.Release != "" && revision.Release != filter.Release {
+			continue
+		}
+		if !filter.ShowDeleted && revision.DeleteTime != nil {
+			continue
+		}
+		if !inMockTimeRange(revision.CreateTime, filter.CreateAfter, filter.CreateBefore) {
+			continue
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+// GetIssue gets the issue by name.
+func (c *mockClient) GetIssue(_ context.Context, issueName string) (*v1pb.Issue, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	issue, ok := c.issueMap[issueName]
+	if !ok {
+		return nil, connect.NewError(connect.CodeNotFound, errors.Errorf("Cannot found issue %s", issueName))
+	}
+	return issue, nil
+}
+
+// ListIssues lists the issues in the project.
+func (c *mockClient) ListIssues(_ context.Context, projectName string, filter *api.IssueFilter) ([]*v1pb.Issue, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	issues := []*v1pb.Issue{}
+	for name, issue := range c.issueMap {
+		if !strings.HasPrefix(name, fmt.Sprintf("%s/", projectName)) {
+			continue
+		}
+		if filter.Status != v1pb.IssueStatus_ISSUE_STATUS_UNSPECIFIED && issue.Status != filter.Status {
+			continue
+		}
+		if filter.Title != "" && issue.Title != filter.Title {
+			continue
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// GetDatabaseSchema gets the schema DDL of the database.
+func (c *mockClient) GetDatabaseSchema(_ context.Context, databaseName string) (*v1pb.DatabaseSchema, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if _, ok := c.databaseMap[databaseName]; !ok {
+		return nil, errors.Errorf("Cannot found database %s", databaseName)
+	}
+	return &v1pb.DatabaseSchema{
+		Schema: c.databaseSchemaMap[databaseName],
+	}, nil
+}
+
+// GetDatabaseMetadata returns the same users and orders tables for all databases.
+func (c *mockClient) GetDatabaseMetadata(_ context.Context, databaseName string) (*v1pb.DatabaseMetadata, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if _, ok := c.databaseMap[databaseName]; !ok {
+		return nil, errors.Errorf("Cannot found database %s", databaseName)
+	}
+	return &v1pb.DatabaseMetadata{
+		Name: fmt.Sprintf("%s/metadata", databaseName),
+		Schemas: []*v1pb.SchemaMetadata{
+			{
+				Name: "public",
+				Tables: []*v1pb.TableMetadata{
+					{
+						Name: "users",
+						Columns: []*v1pb.ColumnMetadata{
+							{Name: "id", Type: "integer"},
+							{Name: "email", Type: "text"},
+							{Name: "phone", Type: "text"},
+						},
+					},
+					{
+						Name: "orders",
+						Columns: []*v1pb.ColumnMetadata{
+							{Name: "id", Type: "integer"},
+							{Name: "customer_email", Type: "text"},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// DiffSchema returns the target schema as the migration DDL unless it equals the database schema.
+func (c *mockClient) DiffSchema(ctx context.Context, request *v1pb.DiffSchemaRequest) (*v1pb.DiffSchemaResponse, error) {
+	current, err := c.GetDatabaseSchema(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	target := request.GetSchema()
+	if strings.TrimSpace(target) == strings.TrimSpace(current.Schema) {
+		return &v1pb.DiffSchemaResponse{}, nil
+	}
+	return &v1pb.DiffSchemaResponse{
+		Diff: target,
+	}, nil
+}
+
 // GetProject gets the project by resource id.
 func (c *mockClient) GetProject(_ context.Context, projectName string) (*v1pb.Project, error) {
 	mu.RLock()
 	defer mu.RUnlock()
 	proj, ok := c.projectMap[projectName]
 	if !ok {
-		return nil, errors.Errorf("Cannot found project %s", projectName)
+		return nil, connect.NewError(connect.CodeNotFound, errors.Errorf("Cannot found project %s", projectName))
 	}
 
 	return proj, nil
@@ -625,16 +1173,21 @@ func (c *mockClient) UpdateProject(ctx context.Context, patch *v1pb.Project, upd
 }
 
 // DeleteProject deletes the project.
-func (c *mockClient) DeleteProject(ctx context.Context, projectName string) error {
+func (c *mockClient) DeleteProject(ctx context.Context, projectName string, purge bool) error {
 	proj, err := c.GetProject(ctx, projectName)
 	if err != nil {
 		return err
 	}
 
-	proj.State = v1pb.State_DELETED
 	mu.Lock()
+	defer mu.Unlock()
+	if purge {
+		delete(c.projectMap, proj.Name)
+		delete(c.projectIAMMap, proj.Name)
+		return nil
+	}
+	proj.State = v1pb.State_DELETED
 	c.projectMap[proj.Name] = proj
-	mu.Unlock()
 
 	return nil
 }
@@ -713,8 +1266,60 @@ func (c *mockClient) UpsertSetting(_ context.Context, upsert *v1pb.Setting, _ []
 	return c.settingMap[upsert.Name], nil
 }
 
+// mockDatabaseConditions is the parsed database group conditions used in the tests.
+var mockDatabaseConditions = map[string]*v1alpha1.Expr{
+	`resource.database_name.startsWith("test-database") && resource.environment_id == "test"`: newMockCallExpr("_&&_", nil,
+		newMockCallExpr("startsWith", newMockResourceExpr("database_name"), newMockStringExpr("test-database")),
+		newMockCallExpr("_==_", nil, newMockResourceExpr("environment_id"), newMockStringExpr("test")),
+	),
+	`resource.database_name == "test-database"`: newMockCallExpr("_==_", nil, newMockResourceExpr("database_name"), newMockStringExpr("test-database")),
+	`resource.environment_id == "test"`:         newMockCallExpr("_==_", nil, newMockResourceExpr("environment_id"), newMockStringExpr("test")),
+	`resource.engine == "POSTGRES"`:             newMockCallExpr("_==_", nil, newMockResourceExpr("engine"), newMockStringExpr("POSTGRES")),
+}
+
+func newMockCallExpr(function string, target *v1alpha1.Expr, args ...*v1alpha1.Expr) *v1alpha1.Expr {
+	return &v1alpha1.Expr{
+		ExprKind: &v1alpha1.Expr_CallExpr{
+			CallExpr: &v1alpha1.Expr_Call{
+				Function: function,
+				Target:   target,
+				Args:     args,
+			},
+		},
+	}
+}
+
+func newMockResourceExpr(field string) *v1alpha1.Expr {
+	return &v1alpha1.Expr{
+		ExprKind: &v1alpha1.Expr_SelectExpr{
+			SelectExpr: &v1alpha1.Expr_Select{
+				Operand: &v1alpha1.Expr{
+					ExprKind: &v1alpha1.Expr_IdentExpr{
+						IdentExpr: &v1alpha1.Expr_Ident{Name: "resource"},
+					},
+				},
+				Field: field,
+			},
+		},
+	}
+}
+
+func newMockStringExpr(value string) *v1alpha1.Expr {
+	return &v1alpha1.Expr{
+		ExprKind: &v1alpha1.Expr_ConstExpr{
+			ConstExpr: &v1alpha1.Constant{
+				ConstantKind: &v1alpha1.Constant_StringValue{StringValue: value},
+			},
+		},
+	}
+}
+
 // ParseExpression parse the expression string.
 func (*mockClient) ParseExpression(_ context.Context, expression string) (*v1alpha1.Expr, error) {
+	if expr, ok := mockDatabaseConditions[expression]; ok {
+		return expr, nil
+	}
+
 	// For mock client, we parse the expression and return a proper structure
 	// The real client would parse the expression, but for testing we create a mock response based on the input
 
@@ -881,7 +1486,7 @@ func (c *mockClient) GetServiceAccount(_ context.Context, name string) (*v1pb.Se
 	defer mu.RUnlock()
 	sa, ok := c.serviceAccountMap[name]
 	if !ok {
-		return nil, errors.Errorf("Cannot found service account %s", name)
+		return nil, connect.NewError(connect.CodeNotFound, errors.Errorf("Cannot found service account %s", name))
 	}
 
 	return sa, nil
@@ -970,7 +1575,7 @@ func (c *mockClient) GetWorkloadIdentity(_ context.Context, name string) (*v1pb.
 	defer mu.RUnlock()
 	wi, ok := c.workloadIdentityMap[name]
 	if !ok {
-		return nil, errors.Errorf("Cannot found workload identity %s", name)
+		return nil, connect.NewError(connect.CodeNotFound, errors.Errorf("Cannot found workload identity %s", name))
 	}
 
 	return wi, nil
@@ -1062,7 +1667,7 @@ func (c *mockClient) GetUser(_ context.Context, userName string) (*v1pb.User, er
 	defer mu.RUnlock()
 	user, ok := c.userMap[userName]
 	if !ok {
-		return nil, errors.Errorf("Cannot found user %s", userName)
+		return nil, connect.NewError(connect.CodeNotFound, errors.Errorf("Cannot found user %s", userName))
 	}
 
 	return user, nil
@@ -1495,3 +2100,125 @@ func (*mockClient) UpdateIdentityProvider(_ context.Context, _ *v1pb.IdentityPro
 func (*mockClient) DeleteIdentityProvider(_ context.Context, _ string) error {
 	return nil
 }
+
+// CheckSQL checks the SQL statement against the SQL review rules.
+func (c *mockClient) CheckSQL(_ context.Context, request *v1pb.CheckRequest) (*v1pb.CheckResponse, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if _, ok := c.databaseMap[request.Name]; !ok {
+		return nil, errors.Errorf("Cannot found database %s", request.Name)
+	}
+	advices := []*v1pb.Advice{}
+	for i, line := range strings.Split(request.Statement, "\n") {
+		if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(line)), "DROP ") {
+			advices = append(advices, &v1pb.Advice{
+				Status:  v1pb.Advice_WARNING,
+				Title:   "statement.disallow-drop",
+				Content: "DROP statement is not recommended",
+				StartPosition: &v1pb.Position{
+					Line: int32(i + 1),
+				},
+			})
+		}
+	}
+	return &v1pb.CheckResponse{
+		Advices: advices,
+	}, nil
+}
+
+// QuerySQL returns a fixed tenant result set for any query.
+func (c *mockClient) QuerySQL(_ context.Context, request *v1pb.QueryRequest) (*v1pb.QueryResponse, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if _, ok := c.databaseMap[request.Name]; !ok {
+		return nil, errors.Errorf("Cannot found database %s", request.Name)
+	}
+	rows := []*v1pb.QueryRow{
+		{
+			Values: []*v1pb.RowValue{
+				{Kind: &v1pb.RowValue_Int64Value{Int64Value: 1}},
+				{Kind: &v1pb.RowValue_StringValue{StringValue: "tenant-a"}},
+			},
+		},
+		{
+			Values: []*v1pb.RowValue{
+				{Kind: &v1pb.RowValue_Int64Value{Int64Value: 2}},
+				{Kind: &v1pb.RowValue_NullValue{}},
+			},
+		},
+	}
+	if request.Limit > 0 && int(request.Limit) < len(rows) {
+		rows = rows[:request.Limit]
+	}
+	return &v1pb.QueryResponse{
+		Results: []*v1pb.QueryResult{
+			{
+				ColumnNames:     []string{"id", "name"},
+				ColumnTypeNames: []string{"INT8", "TEXT"},
+				Rows:            rows,
+			},
+		},
+	}, nil
+}
+
+// newMockAuditLogs returns the audit logs ordered by the create time descending.
+func newMockAuditLogs(parent string) []*v1pb.AuditLog {
+	newTime := func(day int) *timestamppb.Timestamp {
+		return timestamppb.New(time.Date(2025, time.January, day, 0, 0, 0, 0, time.UTC))
+	}
+	return []*v1pb.AuditLog{
+		{
+			Name:       fmt.Sprintf("%s/auditLogs/3", parent),
+			CreateTime: newTime(3),
+			User:       "users/dba@bytebase.com",
+			Method:     "/bytebase.v1.DatabaseService/UpdateDatabase",
+			Severity:   v1pb.AuditLog_ERROR,
+			Resource:   "instances/prod/databases/employee",
+		},
+		{
+			Name:       fmt.Sprintf("%s/auditLogs/2", parent),
+			CreateTime: newTime(2),
+			User:       "users/dev@bytebase.com",
+			Method:     "/bytebase.v1.ProjectService/SetIamPolicy",
+			Severity:   v1pb.AuditLog_INFO,
+			Resource:   `projects/sample-"project"`,
+		},
+		{
+			Name:       fmt.Sprintf("%s/auditLogs/1", parent),
+			CreateTime: newTime(1),
+			User:       "users/dba@bytebase.com",
+			Method:     "/bytebase.v1.ProjectService/SetIamPolicy",
+			Severity:   v1pb.AuditLog_INFO,
+			Resource:   "projects/sample-project",
+		},
+	}
+}
+
+// SearchAuditLogs searches the audit logs in the parent.
+func (c *mockClient) SearchAuditLogs(_ context.Context, parent string, filter *api.AuditLogFilter) ([]*v1pb.AuditLog, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	auditLogs := make([]*v1pb.AuditLog, 0)
+	for _, auditLog := range c.auditLogMap[parent] {
+		if filter.Method != "" && auditLog.Method != filter.Method {
+			continue
+		}
+		if filter.Resource != "" && auditLog.Resource != filter.Resource {
+			continue
+		}
+		if filter.User != "" && auditLog.User != filter.User {
+			continue
+		}
+		if filter.Severity != v1pb.AuditLog_SEVERITY_UNSPECIFIED && auditLog.Severity != filter.Severity {
+			continue
+		}
+		if !inMockTimeRange(auditLog.CreateTime, filter.CreateAfter, filter.CreateBefore) {
+			continue
+		}
+		auditLogs = append(auditLogs, auditLog)
+		if filter.Limit > 0 && len(auditLogs) >= filter.Limit {
+			break
+		}
+	}
+	return auditLogs, nil
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
 )
 
 // MockProviderConfigure is the mock func for provider config.
@@ -23,5 +25,16 @@ func MockProviderConfigure(_ context.Context, d *schema.ResourceData) (interface
 		return nil, diags
 	}
 
+	labelConfig := &api.LabelConfig{
+		DefaultLabels: map[string]string{},
+	}
+	for key, value := range d.Get("default_labels").(map[string]interface{}) {
+		labelConfig.DefaultLabels[key] = value.(string)
+	}
+	for _, prefix := range d.Get("ignore_label_prefixes").([]interface{}) {
+		labelConfig.IgnoreLabelPrefixes = append(labelConfig.IgnoreLabelPrefixes, prefix.(string))
+	}
+	c.(*mockClient).labelConfig = labelConfig
+
 	return c, diags
 }
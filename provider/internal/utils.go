@@ -26,6 +26,8 @@ const (
 	ProjectNamePrefix = "projects/"
 	// DatabaseIDPrefix is the prefix for database unique name.
 	DatabaseIDPrefix = "databases/"
+	// DataSourceIDPrefix is the prefix for instance data source unique name.
+	DataSourceIDPrefix = "dataSources/"
 	// PolicyNamePrefix is the prefix for policy unique name.
 	PolicyNamePrefix = "policies/"
 	// SettingNamePrefix is the prefix for setting unique name.
@@ -227,6 +229,16 @@ func GetInstanceDatabaseID(name string) (string, string, error) {
 	return tokens[0], tokens[1], nil
 }
 
+// GetInstanceDataSourceID will parse the instance resource id and data source id.
+func GetInstanceDataSourceID(name string) (string, string, error) {
+	// the data source name should be instances/{instance-id}/dataSources/{data-source-id}
+	tokens, err := getNameParentTokens(name, InstanceNamePrefix, DataSourceIDPrefix)
+	if err != nil {
+		return "", "", err
+	}
+	return tokens[0], tokens[1], nil
+}
+
 // GetProjectDatabaseGroupID will parse the project resource id and database group resource id.
 func GetProjectDatabaseGroupID(name string) (string, string, error) {
 	// the instance request should be projects/{id}/databaseGroups/{id}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"maps"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+)
+
+// hasIgnoredLabelPrefix returns true if the label key matches any ignored prefix in the provider config.
+func hasIgnoredLabelPrefix(config *api.LabelConfig, key string) bool {
+	for _, prefix := range config.IgnoreLabelPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeLabels merges the provider default labels and the resource labels into the existing labels.
+// If the resource labels are not configured, only the missing default labels are added and other existing labels are kept.
+// Otherwise the result is the default labels overridden by the resource labels, with the existing labels matching the ignored prefixes preserved.
+// The bool result is true if the merged labels differ from the existing labels.
+func mergeLabels(config *api.LabelConfig, labels map[string]string, configured bool, existing map[string]string) (map[string]string, bool) {
+	result := map[string]string{}
+	if !configured {
+		maps.Copy(result, existing)
+		for key, value := range config.DefaultLabels {
+			if _, ok := result[key]; !ok {
+				result[key] = value
+			}
+		}
+		return result, !maps.Equal(result, existing)
+	}
+
+	for key, value := range existing {
+		if hasIgnoredLabelPrefix(config, key) {
+			result[key] = value
+		}
+	}
+	maps.Copy(result, config.DefaultLabels)
+	maps.Copy(result, labels)
+	return result, !maps.Equal(result, existing)
+}
+
+// getLabelsPatch returns the labels to update for the resource with the provider labels config.
+// The bool result is false if the existing labels need no change.
+func getLabelsPatch(config *api.LabelConfig, d *schema.ResourceData, existing map[string]string) (map[string]string, bool) {
+	configured := !d.GetRawConfig().GetAttr("labels").IsNull()
+	return mergeLabels(config, convertToStringMap(d.Get("labels").(map[string]interface{})), configured, existing)
+}
+
+// flattenLabels removes the labels matching the ignored prefixes and the unchanged default labels, so they don't show as drift.
+// The labels already in the state are always kept.
+func flattenLabels(config *api.LabelConfig, labels map[string]string, managed map[string]interface{}) map[string]string {
+	result := map[string]string{}
+	for key, value := range labels {
+		if _, ok := managed[key]; !ok {
+			if hasIgnoredLabelPrefix(config, key) {
+				continue
+			}
+			if defaultValue, ok := config.DefaultLabels[key]; ok && defaultValue == value {
+				continue
+			}
+		}
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+)
+
+func TestMergeLabels(t *testing.T) {
+	config := &api.LabelConfig{
+		DefaultLabels: map[string]string{
+			"team": "dba",
+			"env":  "prod",
+		},
+		IgnoreLabelPrefixes: []string{"auto/"},
+	}
+
+	tests := []struct {
+		name       string
+		labels     map[string]string
+		configured bool
+		existing   map[string]string
+		want       map[string]string
+		wantChange bool
+	}{
+		{
+			name:       "add missing default labels if not configured",
+			existing:   map[string]string{"env": "test", "owner": "ops"},
+			want:       map[string]string{"team": "dba", "env": "test", "owner": "ops"},
+			wantChange: true,
+		},
+		{
+			name:       "no change if default labels exist",
+			existing:   map[string]string{"team": "dba", "env": "test"},
+			want:       map[string]string{"team": "dba", "env": "test"},
+			wantChange: false,
+		},
+		{
+			name:       "resource labels override default labels",
+			labels:     map[string]string{"env": "test", "app": "web"},
+			configured: true,
+			existing:   map[string]string{"owner": "ops", "auto/source": "scanner"},
+			want:       map[string]string{"team": "dba", "env": "test", "app": "web", "auto/source": "scanner"},
+			wantChange: true,
+		},
+		{
+			name:       "no change if only ignored labels differ",
+			labels:     map[string]string{"app": "web"},
+			configured: true,
+			existing:   map[string]string{"team": "dba", "env": "prod", "app": "web", "auto/source": "scanner"},
+			want:       map[string]string{"team": "dba", "env": "prod", "app": "web", "auto/source": "scanner"},
+			wantChange: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, changed := mergeLabels(config, test.labels, test.configured, test.existing)
+			if !maps.Equal(got, test.want) {
+				t.Fatalf("mergeLabels() = %v, want %v", got, test.want)
+			}
+			if changed != test.wantChange {
+				t.Fatalf("mergeLabels() changed = %v, want %v", changed, test.wantChange)
+			}
+		})
+	}
+}
+
+func TestFlattenLabels(t *testing.T) {
+	config := &api.LabelConfig{
+		DefaultLabels: map[string]string{
+			"team": "dba",
+			"env":  "prod",
+		},
+		IgnoreLabelPrefixes: []string{"auto/"},
+	}
+	labels := map[string]string{
+		"team":        "dba",
+		"env":         "test",
+		"app":         "web",
+		"auto/source": "scanner",
+	}
+
+	got := flattenLabels(config, labels, map[string]interface{}{})
+	if want := map[string]string{"env": "test", "app": "web"}; !maps.Equal(got, want) {
+		t.Fatalf("flattenLabels() = %v, want %v", got, want)
+	}
+
+	got = flattenLabels(config, labels, map[string]interface{}{"team": "dba", "auto/source": "scanner"})
+	if !maps.Equal(got, labels) {
+		t.Fatalf("flattenLabels() with managed labels = %v, want %v", got, labels)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
+	"github.com/bytebase/terraform-provider-bytebase/api"
 	"github.com/bytebase/terraform-provider-bytebase/client"
 )
 
@@ -25,6 +26,8 @@ const (
 	settingKeyForCustomHeader      = "custom_header"
 	settingKeyForCustomHeaderName  = "name"
 	settingKeyForCustomHeaderValue = "value"
+	settingKeyForDefaultLabels     = "default_labels"
+	settingKeyForIgnoreLabels      = "ignore_label_prefixes"
 )
 
 var customHeaderNameRegex = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
@@ -76,55 +79,86 @@ func NewProvider() *schema.Provider {
 					},
 				},
 			},
+			settingKeyForDefaultLabels: {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The default labels merged into the labels of bytebase_instance, bytebase_database and bytebase_project. The labels in the resource take precedence over the default labels.",
+			},
+			settingKeyForIgnoreLabels: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Description: "The label key prefixes to ignore in bytebase_instance, bytebase_database and bytebase_project, for example the labels injected by automation. The matching labels are excluded from the diff and preserved on update.",
+			},
 		},
 		ConfigureContextFunc: providerConfigure,
 		DataSourcesMap: map[string]*schema.Resource{
-			"bytebase_instance":               dataSourceInstance(),
-			"bytebase_instance_list":          dataSourceInstanceList(),
-			"bytebase_policy":                 dataSourcePolicy(),
-			"bytebase_policy_list":            dataSourcePolicyList(),
-			"bytebase_project":                dataSourceProject(),
-			"bytebase_project_list":           dataSourceProjectList(),
-			"bytebase_setting":                dataSourceSetting(),
-			"bytebase_user":                   dataSourceUser(),
-			"bytebase_user_list":              dataSourceUserList(),
-			"bytebase_role":                   dataSourceRole(),
-			"bytebase_role_list":              dataSourceRoleList(),
-			"bytebase_group":                  dataSourceGroup(),
-			"bytebase_group_list":             dataSourceGroupList(),
-			"bytebase_database":               dataSourceDatabase(),
-			"bytebase_database_list":          dataSourceDatabaseList(),
-			"bytebase_database_group":         dataSourceDatabaseGroup(),
-			"bytebase_database_group_list":    dataSourceDatabaseGroupList(),
-			"bytebase_review_config":          dataSourceReviewConfig(),
-			"bytebase_review_config_list":     dataSourceReviewConfigList(),
-			"bytebase_iam_policy":             dataSourceIAMPolicy(),
-			"bytebase_environment":            dataSourceEnvironment(),
-			"bytebase_service_account":        dataSourceServiceAccount(),
-			"bytebase_service_account_list":   dataSourceServiceAccountList(),
-			"bytebase_workspace":              dataSourceWorkspace(),
-			"bytebase_workload_identity":      dataSourceWorkloadIdentity(),
-			"bytebase_workload_identity_list": dataSourceWorkloadIdentityList(),
-			"bytebase_idp":                    dataSourceIdentityProvider(),
-			"bytebase_idp_list":               dataSourceIdentityProviderList(),
+			"bytebase_instance":                dataSourceInstance(),
+			"bytebase_instance_list":           dataSourceInstanceList(),
+			"bytebase_policy":                  dataSourcePolicy(),
+			"bytebase_policy_list":             dataSourcePolicyList(),
+			"bytebase_effective_policy":        dataSourceEffectivePolicy(),
+			"bytebase_project":                 dataSourceProject(),
+			"bytebase_project_list":            dataSourceProjectList(),
+			"bytebase_setting":                 dataSourceSetting(),
+			"bytebase_user":                    dataSourceUser(),
+			"bytebase_user_list":               dataSourceUserList(),
+			"bytebase_role":                    dataSourceRole(),
+			"bytebase_role_list":               dataSourceRoleList(),
+			"bytebase_group":                   dataSourceGroup(),
+			"bytebase_group_list":              dataSourceGroupList(),
+			"bytebase_database":                dataSourceDatabase(),
+			"bytebase_database_list":           dataSourceDatabaseList(),
+			"bytebase_database_changelog_list": dataSourceDatabaseChangelogList(),
+			"bytebase_database_revision_list":  dataSourceDatabaseRevisionList(),
+			"bytebase_schema_diff":             dataSourceSchemaDiff(),
+			"bytebase_sql_check":               dataSourceSQLCheck(),
+			"bytebase_sql_query":               dataSourceSQLQuery(),
+			"bytebase_audit_log_list":          dataSourceAuditLogList(),
+			"bytebase_database_group":          dataSourceDatabaseGroup(),
+			"bytebase_database_group_list":     dataSourceDatabaseGroupList(),
+			"bytebase_review_config":           dataSourceReviewConfig(),
+			"bytebase_review_config_list":      dataSourceReviewConfigList(),
+			"bytebase_iam_policy":              dataSourceIAMPolicy(),
+			"bytebase_environment":             dataSourceEnvironment(),
+			"bytebase_service_account":         dataSourceServiceAccount(),
+			"bytebase_service_account_list":    dataSourceServiceAccountList(),
+			"bytebase_workspace":               dataSourceWorkspace(),
+			"bytebase_workload_identity":       dataSourceWorkloadIdentity(),
+			"bytebase_workload_identity_list":  dataSourceWorkloadIdentityList(),
+			"bytebase_idp":                     dataSourceIdentityProvider(),
+			"bytebase_idp_list":                dataSourceIdentityProviderList(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"bytebase_instance":          resourceInstance(),
-			"bytebase_policy":            resourcePolicy(),
-			"bytebase_project":           resourceProjct(),
-			"bytebase_setting":           resourceSetting(),
-			"bytebase_user":              resourceUser(),
-			"bytebase_role":              resourceRole(),
-			"bytebase_group":             resourceGroup(),
-			"bytebase_database":          resourceDatabase(),
-			"bytebase_database_group":    resourceDatabaseGroup(),
-			"bytebase_review_config":     resourceReviewConfig(),
-			"bytebase_iam_policy":        resourceIAMPolicy(),
-			"bytebase_environment":       resourceEnvironment(),
-			"bytebase_service_account":   resourceServiceAccount(),
-			"bytebase_workspace":         resourceWorkspace(),
-			"bytebase_workload_identity": resourceWorkloadIdentity(),
-			"bytebase_idp":               resourceIdentityProvider(),
+			"bytebase_instance":                resourceInstance(),
+			"bytebase_instance_data_source":    resourceInstanceDataSource(),
+			"bytebase_instance_role":           resourceInstanceRole(),
+			"bytebase_policy":                  resourcePolicy(),
+			"bytebase_masking_rule":            resourceMaskingRule(),
+			"bytebase_masking_exemption":       resourceMaskingExemption(),
+			"bytebase_project":                 resourceProjct(),
+			"bytebase_project_databases":       resourceProjectDatabases(),
+			"bytebase_database_adoption":       resourceDatabaseAdoption(),
+			"bytebase_setting":                 resourceSetting(),
+			"bytebase_user":                    resourceUser(),
+			"bytebase_role":                    resourceRole(),
+			"bytebase_group":                   resourceGroup(),
+			"bytebase_database":                resourceDatabase(),
+			"bytebase_database_column_catalog": resourceDatabaseColumnCatalog(),
+			"bytebase_database_label":          resourceDatabaseLabel(),
+			"bytebase_classification_rules":    resourceClassificationRules(),
+			"bytebase_database_group":          resourceDatabaseGroup(),
+			"bytebase_review_config":           resourceReviewConfig(),
+			"bytebase_iam_policy":              resourceIAMPolicy(),
+			"bytebase_environment":             resourceEnvironment(),
+			"bytebase_service_account":         resourceServiceAccount(),
+			"bytebase_workspace":               resourceWorkspace(),
+			"bytebase_workload_identity":       resourceWorkloadIdentity(),
+			"bytebase_idp":                     resourceIdentityProvider(),
 		},
 	}
 }
@@ -140,6 +174,16 @@ func getCustomHeaders(d *schema.ResourceData) map[string]string {
 	return headers
 }
 
+func getLabelConfig(d *schema.ResourceData) *api.LabelConfig {
+	config := &api.LabelConfig{
+		DefaultLabels: convertToStringMap(d.Get(settingKeyForDefaultLabels).(map[string]interface{})),
+	}
+	for _, prefix := range d.Get(settingKeyForIgnoreLabels).([]interface{}) {
+		config.IgnoreLabelPrefixes = append(config.IgnoreLabelPrefixes, prefix.(string))
+	}
+	return config
+}
+
 func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -168,7 +212,13 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 		return nil, diags
 	}
 
-	c, err := client.NewClient(bytebaseURL, email, key, client.WithCustomHeaders(getCustomHeaders(d)))
+	c, err := client.NewClient(
+		bytebaseURL,
+		email,
+		key,
+		client.WithCustomHeaders(getCustomHeaders(d)),
+		client.WithLabelConfig(getLabelConfig(d)),
+	)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
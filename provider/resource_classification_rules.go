@@ -0,0 +1,452 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func resourceClassificationRules() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Generate the column catalog from the classification rules across the databases matching the selector. The databases metadata is read on every plan, so the per-column changes show in the plan. Other columns in the database catalog are left alone.",
+		CreateContext: resourceClassificationRulesUpsert,
+		ReadContext:   resourceClassificationRulesRead,
+		UpdateContext: resourceClassificationRulesUpsert,
+		DeleteContext: resourceClassificationRulesDelete,
+		CustomizeDiff: resolveClassificationRulesDiff,
+		Schema: map[string]*schema.Schema{
+			"selector": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "Select the databases to apply the rules.",
+				Elem: &schema.Resource{
+					Schema: getDatabaseSelectorSchema(),
+				},
+			},
+			"rules": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The classification rules. The first rule matching the column is applied.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"column_pattern": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsValidRegExp,
+							Description:  "The regular expression to match the column name, for example (?i)email|phone.",
+						},
+						"table_pattern": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsValidRegExp,
+							Description:  "The regular expression to match the table name. Match all tables if not set.",
+						},
+						"schema_pattern": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsValidRegExp,
+							Description:  "The regular expression to match the schema name. Match all schemas if not set.",
+						},
+						"semantic_type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The semantic type id to set on the matched columns.",
+						},
+						"classification": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The classification id to set on the matched columns.",
+						},
+					},
+				},
+			},
+			"columns": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The columns catalog managed by the rules.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"database": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The database full name.",
+						},
+						"schema": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The schema name.",
+						},
+						"table": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The table name.",
+						},
+						"column": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The column name.",
+						},
+						"semantic_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The semantic type id.",
+						},
+						"classification": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The classification id.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type classificationRule struct {
+	column         *regexp.Regexp
+	table          *regexp.Regexp
+	schema         *regexp.Regexp
+	semanticType   string
+	classification string
+}
+
+// classifiedColumn is the catalog generated for a column by the rules.
+type classifiedColumn struct {
+	database       string
+	schema         string
+	table          string
+	column         string
+	semanticType   string
+	classification string
+}
+
+func convertToClassificationRules(rawList []interface{}) ([]*classificationRule, error) {
+	rules := []*classificationRule{}
+	for _, raw := range rawList {
+		rawRule := raw.(map[string]interface{})
+		rule := &classificationRule{
+			semanticType:   rawRule["semantic_type"].(string),
+			classification: rawRule["classification"].(string),
+		}
+		if rule.semanticType == "" && rule.classification == "" {
+			return nil, errors.Errorf("rule for column %s must set semantic_type or classification", rawRule["column_pattern"].(string))
+		}
+		for key, target := range map[string]**regexp.Regexp{
+			"column_pattern": &rule.column,
+			"table_pattern":  &rule.table,
+			"schema_pattern": &rule.schema,
+		} {
+			pattern := rawRule[key].(string)
+			if pattern == "" {
+				continue
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid %s %s", key, pattern)
+			}
+			*target = re
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// matchClassificationRule returns the first rule matching the column, or nil if no rule matches.
+func matchClassificationRule(rules []*classificationRule, schemaName, tableName, columnName string) *classificationRule {
+	for _, rule := range rules {
+		if rule.schema != nil && !rule.schema.MatchString(schemaName) {
+			continue
+		}
+		if rule.table != nil && !rule.table.MatchString(tableName) {
+			continue
+		}
+		if rule.column.MatchString(columnName) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// classifyDatabaseColumns reads the metadata of the databases matching the selector and returns the columns matching the rules.
+func classifyDatabaseColumns(ctx context.Context, client api.Client, selector map[string]interface{}, rawRules []interface{}) ([]*classifiedColumn, error) {
+	rules, err := convertToClassificationRules(rawRules)
+	if err != nil {
+		return nil, err
+	}
+	databaseNames, err := resolveDatabaseSelector(ctx, client, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := []*classifiedColumn{}
+	for _, databaseName := range databaseNames {
+		startTime := time.Now()
+		metadata, err := client.GetDatabaseMetadata(ctx, databaseName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get metadata for database %s", databaseName)
+		}
+		for _, schemaMetadata := range metadata.Schemas {
+			for _, table := range schemaMetadata.Tables {
+				for _, column := range table.Columns {
+					rule := matchClassificationRule(rules, schemaMetadata.Name, table.Name, column.Name)
+					if rule == nil {
+						continue
+					}
+					columns = append(columns, &classifiedColumn{
+						database:       databaseName,
+						schema:         schemaMetadata.Name,
+						table:          table.Name,
+						column:         column.Name,
+						semanticType:   rule.semanticType,
+						classification: rule.classification,
+					})
+				}
+			}
+		}
+		tflog.Debug(ctx, "[classify database columns]", map[string]interface{}{
+			"database": databaseName,
+			"ms":       time.Since(startTime).Milliseconds(),
+		})
+	}
+	return columns, nil
+}
+
+func flattenClassifiedColumns(columns []*classifiedColumn) []interface{} {
+	columnList := []interface{}{}
+	for _, column := range columns {
+		columnList = append(columnList, map[string]interface{}{
+			"database":       column.database,
+			"schema":         column.schema,
+			"table":          column.table,
+			"column":         column.column,
+			"semantic_type":  column.semanticType,
+			"classification": column.classification,
+		})
+	}
+	return columnList
+}
+
+func convertToClassifiedColumns(rawList []interface{}) []*classifiedColumn {
+	columns := []*classifiedColumn{}
+	for _, raw := range rawList {
+		rawColumn := raw.(map[string]interface{})
+		columns = append(columns, &classifiedColumn{
+			database:       rawColumn["database"].(string),
+			schema:         rawColumn["schema"].(string),
+			table:          rawColumn["table"].(string),
+			column:         rawColumn["column"].(string),
+			semanticType:   rawColumn["semantic_type"].(string),
+			classification: rawColumn["classification"].(string),
+		})
+	}
+	return columns
+}
+
+func resolveClassificationRulesDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if !diff.NewValueKnown("selector") || !diff.NewValueKnown("rules") {
+		return diff.SetNewComputed("columns")
+	}
+
+	columns, err := classifyDatabaseColumns(ctx, m.(api.Client), getSelectorConfig(diff.Get("selector").([]interface{})), diff.Get("rules").([]interface{}))
+	if err != nil {
+		return err
+	}
+	return diff.SetNew("columns", flattenClassifiedColumns(columns))
+}
+
+func resourceClassificationRulesUpsert(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+
+	columns, err := classifyDatabaseColumns(ctx, c, getSelectorConfig(d.Get("selector").([]interface{})), d.Get("rules").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Clear the catalog set by the rules before for the columns no longer matched.
+	oldColumns, _ := d.GetChange("columns")
+	matched := map[string]bool{}
+	for _, column := range columns {
+		matched[getColumnCatalogID(column.database, column.schema, column.table, column.column)] = true
+	}
+	unmatched := []*classifiedColumn{}
+	for _, column := range convertToClassifiedColumns(oldColumns.(*schema.Set).List()) {
+		if !matched[getColumnCatalogID(column.database, column.schema, column.table, column.column)] {
+			unmatched = append(unmatched, column)
+		}
+	}
+
+	var diags diag.Diagnostics
+	applied := []*classifiedColumn{}
+	for databaseName, databaseColumns := range groupClassifiedColumns(columns) {
+		if err := applyClassifiedColumns(ctx, c, databaseName, databaseColumns, false); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to update database catalog",
+				Detail:   fmt.Sprintf("Update catalog for database %s failed, error: %v", databaseName, err),
+			})
+			continue
+		}
+		applied = append(applied, databaseColumns...)
+	}
+	for databaseName, databaseColumns := range groupClassifiedColumns(unmatched) {
+		if err := applyClassifiedColumns(ctx, c, databaseName, databaseColumns, true); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to clear database catalog",
+				Detail:   fmt.Sprintf("Clear catalog for database %s failed, error: %v", databaseName, err),
+			})
+		}
+	}
+
+	if d.Id() == "" {
+		d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	}
+	if err := d.Set("columns", flattenClassifiedColumns(applied)); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
+func groupClassifiedColumns(columns []*classifiedColumn) map[string][]*classifiedColumn {
+	databaseColumns := map[string][]*classifiedColumn{}
+	for _, column := range columns {
+		databaseColumns[column.database] = append(databaseColumns[column.database], column)
+	}
+	return databaseColumns
+}
+
+// applyClassifiedColumns sets the semantic type and classification for the columns in the database catalog.
+// If reset is true, the semantic type and classification set by the rules are removed instead.
+// The column labels and other columns in the catalog are kept.
+func applyClassifiedColumns(ctx context.Context, client api.Client, databaseName string, columns []*classifiedColumn, reset bool) error {
+	catalogMutex.Lock()
+	defer catalogMutex.Unlock()
+
+	current, err := client.GetDatabaseCatalog(ctx, databaseName)
+	if err != nil {
+		return err
+	}
+	patch := &v1pb.DatabaseCatalog{
+		Name: fmt.Sprintf("%s%s", databaseName, internal.DatabaseCatalogNameSuffix),
+	}
+	for _, column := range columns {
+		columnCatalog := &v1pb.ColumnCatalog{
+			Name: column.column,
+		}
+		table := findTableCatalogInDatabase(current, column.schema, column.table)
+		if existed := findColumnCatalog(table, column.column); existed != nil {
+			columnCatalog = proto.Clone(existed).(*v1pb.ColumnCatalog)
+		}
+		if reset {
+			if column.semanticType != "" && columnCatalog.SemanticType == column.semanticType {
+				columnCatalog.SemanticType = ""
+			}
+			if column.classification != "" && columnCatalog.Classification == column.classification {
+				columnCatalog.Classification = ""
+			}
+		} else {
+			if column.semanticType != "" {
+				columnCatalog.SemanticType = column.semanticType
+			}
+			if column.classification != "" {
+				columnCatalog.Classification = column.classification
+			}
+		}
+
+		patchSchema := findSchemaCatalog(patch, column.schema)
+		if patchSchema == nil {
+			patchSchema = &v1pb.SchemaCatalog{Name: column.schema}
+			patch.Schemas = append(patch.Schemas, patchSchema)
+		}
+		patchTable := findTableCatalog(patchSchema, column.table)
+		if patchTable == nil {
+			patchTable = &v1pb.TableCatalog{
+				Name: column.table,
+				Kind: &v1pb.TableCatalog_Columns_{
+					Columns: &v1pb.TableCatalog_Columns{},
+				},
+			}
+			patchSchema.Tables = append(patchSchema.Tables, patchTable)
+		}
+		patchTable.GetColumns().Columns = append(patchTable.GetColumns().Columns, columnCatalog)
+	}
+
+	merged := mergeDatabaseCatalog(current, patch)
+	merged.Name = patch.Name
+	_, err = client.UpdateDatabaseCatalog(ctx, merged)
+	return err
+}
+
+func resourceClassificationRulesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+
+	// Keep the columns with the catalog still applied, so the drifted columns are applied again in the next apply.
+	applied := []*classifiedColumn{}
+	for databaseName, columns := range groupClassifiedColumns(convertToClassifiedColumns(d.Get("columns").(*schema.Set).List())) {
+		catalog, err := c.GetDatabaseCatalog(ctx, databaseName)
+		if err != nil {
+			// The columns in the deleted database are no longer applied.
+			if internal.IsNotFoundError(err) {
+				tflog.Warn(ctx, fmt.Sprintf("database %s not found, drop its columns from the state", databaseName))
+				continue
+			}
+			return diag.Errorf("failed to get catalog for database %s with error: %v", databaseName, err)
+		}
+		for _, column := range columns {
+			columnCatalog := findColumnCatalog(findTableCatalogInDatabase(catalog, column.schema, column.table), column.column)
+			if columnCatalog == nil {
+				continue
+			}
+			if column.semanticType != "" && columnCatalog.SemanticType != column.semanticType {
+				continue
+			}
+			if column.classification != "" && columnCatalog.Classification != column.classification {
+				continue
+			}
+			applied = append(applied, column)
+		}
+	}
+
+	if err := d.Set("columns", flattenClassifiedColumns(applied)); err != nil {
+		return diag.Errorf("cannot set columns: %s", err.Error())
+	}
+	return nil
+}
+
+func resourceClassificationRulesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+
+	var diags diag.Diagnostics
+	for databaseName, columns := range groupClassifiedColumns(convertToClassifiedColumns(d.Get("columns").(*schema.Set).List())) {
+		if err := applyClassifiedColumns(ctx, c, databaseName, columns, true); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to clear database catalog",
+				Detail:   fmt.Sprintf("Clear catalog for database %s failed, error: %v", databaseName, err),
+			})
+		}
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func TestAccClassificationRules(t *testing.T) {
+	identifier := "pii"
+	resourceName := fmt.Sprintf("bytebase_classification_rules.%s", identifier)
+	instanceID := "classification-instance"
+
+	base := testAccCheckInstanceResource("classification_instance", instanceID, "classification instance", "POSTGRES", "environments/test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "bytebase_classification_rules" "%s" {
+	selector {
+		instances = [bytebase_instance.classification_instance.name]
+		query     = "test-database-labels"
+	}
+
+	rules {
+		column_pattern = "(?i)email"
+		semantic_type  = "email-mask"
+		classification = "1-1"
+	}
+
+	rules {
+		column_pattern = "(?i)email|phone"
+		table_pattern  = "^users$"
+		classification = "1-2"
+	}
+}
+
+data "bytebase_database" "classified" {
+	name       = "${bytebase_instance.classification_instance.name}/databases/test-database-labels"
+	depends_on = [bytebase_classification_rules.%s]
+}
+`, base, identifier, identifier),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists(resourceName),
+					// users.email and orders.customer_email match the first rule, users.phone matches the second rule.
+					resource.TestCheckResourceAttr(resourceName, "columns.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "columns.*", map[string]string{
+						"table":          "users",
+						"column":         "phone",
+						"semantic_type":  "",
+						"classification": "1-2",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "columns.*", map[string]string{
+						"table":          "orders",
+						"column":         "customer_email",
+						"semantic_type":  "email-mask",
+						"classification": "1-1",
+					}),
+					resource.TestCheckResourceAttr("data.bytebase_database.classified", "catalog.0.schemas.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestMatchClassificationRule(t *testing.T) {
+	rules, err := convertToClassificationRules([]interface{}{
+		map[string]interface{}{
+			"column_pattern": "(?i)email",
+			"table_pattern":  "",
+			"schema_pattern": "^public$",
+			"semantic_type":  "email-mask",
+			"classification": "",
+		},
+		map[string]interface{}{
+			"column_pattern": "phone",
+			"table_pattern":  "^users$",
+			"schema_pattern": "",
+			"semantic_type":  "",
+			"classification": "1-2",
+		},
+	})
+	if err != nil {
+		t.Fatalf("convertToClassificationRules() error = %v", err)
+	}
+
+	tests := []struct {
+		schema string
+		table  string
+		column string
+		want   string
+	}{
+		{schema: "public", table: "users", column: "EMAIL", want: "email-mask"},
+		{schema: "private", table: "users", column: "email", want: ""},
+		{schema: "private", table: "users", column: "phone", want: "1-2"},
+		{schema: "public", table: "orders", column: "phone", want: ""},
+	}
+	for _, test := range tests {
+		got := ""
+		if rule := matchClassificationRule(rules, test.schema, test.table, test.column); rule != nil {
+			got = rule.semanticType + rule.classification
+		}
+		if got != test.want {
+			t.Errorf("matchClassificationRule(%s.%s.%s) = %q, want %q", test.schema, test.table, test.column, got, test.want)
+		}
+	}
+
+	if _, err := convertToClassificationRules([]interface{}{
+		map[string]interface{}{
+			"column_pattern": "email",
+			"table_pattern":  "",
+			"schema_pattern": "",
+			"semantic_type":  "",
+			"classification": "",
+		},
+	}); err == nil {
+		t.Fatal("convertToClassificationRules() without semantic_type and classification returned no error")
+	}
+}
+
+// catalogErrorClient fails to get the database catalog with the error.
+type catalogErrorClient struct {
+	api.Client
+	err error
+}
+
+func (c *catalogErrorClient) GetDatabaseCatalog(_ context.Context, _ string) (*v1pb.DatabaseCatalog, error) {
+	return nil, c.err
+}
+
+func TestClassificationRulesReadCatalogError(t *testing.T) {
+	for _, test := range []struct {
+		err       error
+		wantError bool
+	}{
+		{connect.NewError(connect.CodeUnavailable, errors.New("unavailable")), true},
+		// The columns in the deleted database are dropped from the state.
+		{connect.NewError(connect.CodeNotFound, errors.New("database not found")), false},
+	} {
+		d := schema.TestResourceDataRaw(t, resourceClassificationRules().Schema, map[string]interface{}{})
+		d.SetId("classification")
+		if err := d.Set("columns", flattenClassifiedColumns([]*classifiedColumn{
+			{database: "instances/i/databases/db", schema: "public", table: "users", column: "email", classification: "1-1"},
+		})); err != nil {
+			t.Fatal(err)
+		}
+
+		diags := resourceClassificationRulesRead(context.Background(), d, &catalogErrorClient{err: test.err})
+		if diags.HasError() != test.wantError {
+			t.Fatalf("resourceClassificationRulesRead(%v) has error = %v, want %v", test.err, diags.HasError(), test.wantError)
+		}
+		if !test.wantError && d.Get("columns").(*schema.Set).Len() != 0 {
+			t.Errorf("resourceClassificationRulesRead(%v) kept the columns in the deleted database", test.err)
+		}
+	}
+}
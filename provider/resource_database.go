@@ -3,13 +3,18 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
 
 	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
 
@@ -17,6 +22,14 @@ import (
 	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
 )
 
+const (
+	catalogModeAuthoritative = "authoritative"
+	catalogModeMerge         = "merge"
+)
+
+// catalogMutex serializes the read-modify-write on database catalogs.
+var catalogMutex sync.Mutex
+
 func resourceDatabase() *schema.Resource {
 	return &schema.Resource{
 		Description:   "The database resource.",
@@ -24,9 +37,14 @@ func resourceDatabase() *schema.Resource {
 		ReadContext:   resourceDatabaseRead,
 		UpdateContext: resourceDatabaseUpdate,
 		DeleteContext: resourceDatabaseDelete,
+		CustomizeDiff: validateDatabaseDropOnDestroy,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -99,6 +117,68 @@ func resourceDatabase() *schema.Resource {
 				Description: "The deployment and policy control labels.",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"create_if_missing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Create the database on the instance through the Bytebase create database issue if it doesn't exist, and wait for the issue rollout. The issue rollout may need approval depending on the project setting, the next apply waits for the open issue instead of creating another one.",
+			},
+			"create_options": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "The options to create the database, only used when create_if_missing is true and the database doesn't exist.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"character_set": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The database character set, for example utf8mb4 for MySQL or UTF8 for PostgreSQL.",
+						},
+						"collation": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The database collation, for example utf8mb4_general_ci for MySQL.",
+						},
+						"owner": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The database owner role, only for PostgreSQL-compatible engines.",
+						},
+						"table": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The initial table (collection) name, required by MongoDB since the database is created with its first collection.",
+						},
+						"cluster": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The cluster name to create the database ON CLUSTER, only for ClickHouse.",
+						},
+					},
+				},
+			},
+			"drop_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Drop the physical database through the Bytebase issue when the resource is destroyed, instead of transferring the database to the default project, and wait for the issue rollout in the delete timeout. If the rollout is still pending, for example waiting for the approval, the resource is removed with a warning. Requires drop_confirmation to be the database full name. Only supported for MySQL-compatible engines and ClickHouse.",
+			},
+			"drop_confirmation": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Must equal to the database full name to confirm drop_on_destroy.",
+			},
+			"catalog_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  catalogModeAuthoritative,
+				ValidateFunc: validation.StringInSlice([]string{
+					catalogModeAuthoritative,
+					catalogModeMerge,
+				}, false),
+				Description: "The catalog management mode. In authoritative mode, the catalog replaces the whole database catalog. In merge mode, only the tables and columns declared in the catalog are patched, and other tables and columns are left alone, for example the classifications added in the Bytebase UI. The table classification is kept if it's not set in the catalog.",
+			},
 			"catalog": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -261,11 +341,145 @@ func getDatabaseInstanceResourceSchema() *schema.Schema {
 	}
 }
 
+func validateDatabaseDropOnDestroy(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.Get("drop_on_destroy").(bool) {
+		return nil
+	}
+	databaseName := diff.Get("name").(string)
+	if confirmation := diff.Get("drop_confirmation").(string); confirmation != databaseName {
+		return errors.Errorf("drop_on_destroy requires drop_confirmation to be %q, got %q", databaseName, confirmation)
+	}
+	return nil
+}
+
+func createDatabaseIfMissing(ctx context.Context, c api.Client, d *schema.ResourceData) diag.Diagnostics {
+	databaseName := d.Get("name").(string)
+	_, err := c.GetDatabase(ctx, databaseName)
+	if err == nil {
+		return nil
+	}
+	if !internal.IsNotFoundError(err) {
+		return diag.Errorf("failed to get the database %s with error: %v", databaseName, err.Error())
+	}
+	tflog.Debug(ctx, fmt.Sprintf("database %s not found, create it", databaseName))
+
+	instanceID, databaseID, err := internal.GetInstanceDatabaseID(databaseName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	config := &v1pb.Plan_CreateDatabaseConfig{
+		Target:      fmt.Sprintf("%s%s", internal.InstanceNamePrefix, instanceID),
+		Database:    databaseID,
+		Environment: d.Get("environment").(string),
+	}
+	if rawList := d.Get("create_options").([]interface{}); len(rawList) == 1 && rawList[0] != nil {
+		raw := rawList[0].(map[string]interface{})
+		config.CharacterSet = raw["character_set"].(string)
+		config.Collation = raw["collation"].(string)
+		config.Owner = raw["owner"].(string)
+		config.Table = raw["table"].(string)
+		config.Cluster = raw["cluster"].(string)
+	}
+
+	// Wait for the open issue from the previous apply instead of creating the database again.
+	projectName := d.Get("project").(string)
+	issues, err := c.ListIssues(ctx, projectName, &api.IssueFilter{
+		Status: v1pb.IssueStatus_OPEN,
+		Title:  api.GetCreateDatabaseIssueTitle(databaseName),
+	})
+	if err != nil {
+		return diag.Errorf("failed to list the issues to create the database %s with error: %v", databaseName, err.Error())
+	}
+	var issue *v1pb.Issue
+	if len(issues) > 0 {
+		issue = issues[0]
+		tflog.Info(ctx, fmt.Sprintf("the database %s is being created in the issue %s, wait for it", databaseName, issue.Name))
+	} else {
+		issue, err = c.CreateDatabase(ctx, projectName, config)
+		if err != nil {
+			return diag.Errorf("failed to create the database %s with error: %v", databaseName, err.Error())
+		}
+	}
+
+	if err := waitForIssueRollout(ctx, c, issue, d.Timeout(schema.TimeoutCreate)); err != nil {
+		if errors.Is(err, errRolloutPending) {
+			return diag.Errorf("database %s is not created yet, apply again after the issue %s is approved and rolled out: %v", databaseName, issue.Name, err.Error())
+		}
+		return diag.Errorf("failed to create the database %s, check the issue %s for details and close it before applying again, error: %v", databaseName, issue.Name, err.Error())
+	}
+	if _, err := c.GetDatabase(ctx, databaseName); err != nil {
+		return diag.Errorf("failed to get the database %s created in the issue %s with error: %v", databaseName, issue.Name, err.Error())
+	}
+	return nil
+}
+
+// getDropDatabaseStatement returns the statement to drop the database in its own connection.
+// Engines like PostgreSQL cannot drop the currently connected database, so only the engines allowing it are supported.
+func getDropDatabaseStatement(engine v1pb.Engine, databaseID string) (string, error) {
+	switch engine {
+	case v1pb.Engine_MYSQL, v1pb.Engine_MARIADB, v1pb.Engine_TIDB, v1pb.Engine_OCEANBASE, v1pb.Engine_STARROCKS, v1pb.Engine_DORIS, v1pb.Engine_CLICKHOUSE:
+		return fmt.Sprintf("DROP DATABASE `%s`;", strings.ReplaceAll(databaseID, "`", "``")), nil
+	default:
+		return "", errors.Errorf("drop_on_destroy is not supported for %s engine", engine.String())
+	}
+}
+
+func dropDatabase(ctx context.Context, c api.Client, d *schema.ResourceData) diag.Diagnostics {
+	databaseName := d.Id()
+	if confirmation := d.Get("drop_confirmation").(string); confirmation != databaseName {
+		return diag.Errorf("drop_on_destroy requires drop_confirmation to be %q, got %q", databaseName, confirmation)
+	}
+
+	instanceID, databaseID, err := internal.GetInstanceDatabaseID(databaseName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	instance, err := c.GetInstance(ctx, fmt.Sprintf("%s%s", internal.InstanceNamePrefix, instanceID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	statement, err := getDropDatabaseStatement(instance.Engine, databaseID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	issue, err := c.DropDatabase(ctx, d.Get("project").(string), databaseName, statement)
+	if err != nil {
+		return diag.Errorf("failed to drop the database %s with error: %v", databaseName, err.Error())
+	}
+
+	if err := waitForIssueRollout(ctx, c, issue, d.Timeout(schema.TimeoutDelete)); err != nil {
+		if !errors.Is(err, errRolloutPending) {
+			return diag.Errorf("failed to drop the database %s, check the issue %s for details, error: %v", databaseName, issue.Name, err.Error())
+		}
+		// The drop issue is submitted, so remove the resource to avoid submitting it again.
+		d.SetId("")
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Database drop pending",
+				Detail:   fmt.Sprintf("Database %s is not dropped yet, it will be dropped once the issue %s is approved and rolled out: %v", databaseName, issue.Name, err.Error()),
+			},
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
 func resourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(api.Client)
 	databaseName := d.Get("name").(string)
 	projectName := d.Get("project").(string)
 
+	var diags diag.Diagnostics
+	if d.Id() == "" && d.Get("create_if_missing").(bool) {
+		diags = createDatabaseIfMissing(ctx, c, d)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
 	database := &v1pb.Database{
 		Name:    databaseName,
 		Project: projectName,
@@ -277,9 +491,16 @@ func resourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		database.Environment = &env
 		updateMasks = append(updateMasks, "environment")
 	}
-	if config := rawConfig.GetAttr("labels"); !config.IsNull() {
-		database.Labels = convertToStringMap(d.Get("labels").(map[string]interface{}))
-		updateMasks = append(updateMasks, "labels")
+	labelConfig := c.GetLabelConfig()
+	if config := rawConfig.GetAttr("labels"); !config.IsNull() || len(labelConfig.DefaultLabels) > 0 {
+		existed, err := c.GetDatabase(ctx, databaseName)
+		if err != nil {
+			return diag.Errorf("failed to get the database %s with error: %v", databaseName, err.Error())
+		}
+		if labels, ok := getLabelsPatch(labelConfig, d, existed.Labels); ok {
+			database.Labels = labels
+			updateMasks = append(updateMasks, "labels")
+		}
 	}
 
 	if _, err := c.UpdateDatabase(ctx, database, updateMasks); err != nil {
@@ -292,7 +513,7 @@ func resourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, m inter
 			return diag.Errorf("failed to convert database catalog %v with error: %v", databaseName, err.Error())
 		}
 		if catalog != nil {
-			if _, err := c.UpdateDatabaseCatalog(ctx, catalog); err != nil {
+			if err := updateDatabaseCatalog(ctx, c, databaseName, catalog, d.Get("catalog_mode").(string) == catalogModeMerge); err != nil {
 				return diag.Errorf("failed to update database catalog %v with error: %v", databaseName, err.Error())
 			}
 		}
@@ -300,7 +521,7 @@ func resourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 	d.SetId(databaseName)
 
-	return resourceDatabaseRead(ctx, d, m)
+	return append(diags, resourceDatabaseRead(ctx, d, m)...)
 }
 
 func resourceDatabaseRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -312,13 +533,57 @@ func resourceDatabaseRead(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.FromErr(err)
 	}
 
-	return setDatabase(ctx, c, d, database)
+	managed := d.Get("labels").(map[string]interface{})
+	if d.Get("catalog_mode").(string) != catalogModeMerge {
+		if diags := setDatabase(ctx, c, d, database); diags.HasError() {
+			return diags
+		}
+		return setDatabaseLabels(c, d, database, managed)
+	}
+
+	// Only keep the declared tables and columns in the state for the merge mode.
+	declared, err := convertToV1DatabaseCatalog(d, databaseName)
+	if err != nil {
+		return diag.Errorf("failed to convert database catalog %v with error: %v", databaseName, err.Error())
+	}
+	if diags := setDatabase(ctx, c, d, database); diags.HasError() {
+		return diags
+	}
+	if diags := setDatabaseLabels(c, d, database, managed); diags.HasError() {
+		return diags
+	}
+	if declared == nil {
+		if err := d.Set("catalog", nil); err != nil {
+			return diag.Errorf("cannot set catalog for database: %s", err.Error())
+		}
+		return nil
+	}
+	catalog, err := convertToV1DatabaseCatalog(d, databaseName)
+	if err != nil {
+		return diag.Errorf("failed to convert database catalog %v with error: %v", databaseName, err.Error())
+	}
+	if err := d.Set("catalog", flattenDatabaseCatalog(filterDatabaseCatalog(catalog, declared))); err != nil {
+		return diag.Errorf("cannot set catalog for database: %s", err.Error())
+	}
+	return nil
+}
+
+// setDatabaseLabels sets the database labels without the ignored and unchanged default labels in the provider config.
+func setDatabaseLabels(client api.Client, d *schema.ResourceData, database *v1pb.Database, managed map[string]interface{}) diag.Diagnostics {
+	if err := d.Set("labels", flattenLabels(client.GetLabelConfig(), database.Labels, managed)); err != nil {
+		return diag.Errorf("cannot set labels for database: %s", err.Error())
+	}
+	return nil
 }
 
 func resourceDatabaseDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(api.Client)
 	databaseName := d.Id()
 
+	if d.Get("drop_on_destroy").(bool) {
+		return dropDatabase(ctx, c, d)
+	}
+
 	var diags diag.Diagnostics
 
 	diags = append(diags, diag.Diagnostic{
@@ -590,3 +855,130 @@ func convertToV1DatabaseCatalog(d *schema.ResourceData, databaseName string) (*v
 
 	return catalog, nil
 }
+
+// updateDatabaseCatalog updates the database catalog.
+// In merge mode, the patch is merged into the current catalog so the undeclared tables and columns are kept.
+func updateDatabaseCatalog(ctx context.Context, client api.Client, databaseName string, patch *v1pb.DatabaseCatalog, merge bool) error {
+	if !merge {
+		_, err := client.UpdateDatabaseCatalog(ctx, patch)
+		return err
+	}
+
+	catalogMutex.Lock()
+	defer catalogMutex.Unlock()
+
+	current, err := client.GetDatabaseCatalog(ctx, databaseName)
+	if err != nil {
+		return err
+	}
+	merged := mergeDatabaseCatalog(current, patch)
+	merged.Name = patch.Name
+	_, err = client.UpdateDatabaseCatalog(ctx, merged)
+	return err
+}
+
+// mergeDatabaseCatalog merges the patch into the catalog.
+// The tables and columns in the patch replace the ones with the same name, others in the catalog are kept.
+// The table classification is only replaced by the non-empty classification in the patch.
+func mergeDatabaseCatalog(catalog, patch *v1pb.DatabaseCatalog) *v1pb.DatabaseCatalog {
+	merged := proto.Clone(catalog).(*v1pb.DatabaseCatalog)
+	for _, patchSchema := range patch.Schemas {
+		schemaCatalog := findSchemaCatalog(merged, patchSchema.Name)
+		if schemaCatalog == nil {
+			merged.Schemas = append(merged.Schemas, proto.Clone(patchSchema).(*v1pb.SchemaCatalog))
+			continue
+		}
+		for _, patchTable := range patchSchema.Tables {
+			table := findTableCatalog(schemaCatalog, patchTable.Name)
+			if table == nil {
+				schemaCatalog.Tables = append(schemaCatalog.Tables, proto.Clone(patchTable).(*v1pb.TableCatalog))
+				continue
+			}
+			// Keep the table classification if the patch doesn't declare one.
+			if patchTable.Classification != "" {
+				table.Classification = patchTable.Classification
+			}
+			if patchTable.GetObjectSchema() != nil || table.GetObjectSchema() != nil {
+				table.Kind = proto.Clone(patchTable).(*v1pb.TableCatalog).Kind
+				continue
+			}
+			columns := table.GetColumns()
+			if columns == nil {
+				columns = &v1pb.TableCatalog_Columns{}
+				table.Kind = &v1pb.TableCatalog_Columns_{Columns: columns}
+			}
+			for _, patchColumn := range patchTable.GetColumns().GetColumns() {
+				index := slices.IndexFunc(columns.Columns, func(column *v1pb.ColumnCatalog) bool {
+					return column.Name == patchColumn.Name
+				})
+				if index < 0 {
+					columns.Columns = append(columns.Columns, proto.Clone(patchColumn).(*v1pb.ColumnCatalog))
+					continue
+				}
+				columns.Columns[index] = proto.Clone(patchColumn).(*v1pb.ColumnCatalog)
+			}
+		}
+	}
+	return merged
+}
+
+// filterDatabaseCatalog returns the tables and columns in the catalog declared in the filter.
+func filterDatabaseCatalog(catalog, filter *v1pb.DatabaseCatalog) *v1pb.DatabaseCatalog {
+	filtered := &v1pb.DatabaseCatalog{
+		Name: catalog.Name,
+	}
+	for _, filterSchema := range filter.Schemas {
+		schemaCatalog := findSchemaCatalog(catalog, filterSchema.Name)
+		if schemaCatalog == nil {
+			continue
+		}
+		filteredSchema := &v1pb.SchemaCatalog{
+			Name: schemaCatalog.Name,
+		}
+		for _, filterTable := range filterSchema.Tables {
+			table := findTableCatalog(schemaCatalog, filterTable.Name)
+			if table == nil {
+				continue
+			}
+			if table.GetObjectSchema() != nil {
+				filteredSchema.Tables = append(filteredSchema.Tables, table)
+				continue
+			}
+			columns := []*v1pb.ColumnCatalog{}
+			for _, column := range table.GetColumns().GetColumns() {
+				if slices.ContainsFunc(filterTable.GetColumns().GetColumns(), func(filterColumn *v1pb.ColumnCatalog) bool {
+					return filterColumn.Name == column.Name
+				}) {
+					columns = append(columns, column)
+				}
+			}
+			filteredSchema.Tables = append(filteredSchema.Tables, &v1pb.TableCatalog{
+				Name:           table.Name,
+				Classification: table.Classification,
+				Kind: &v1pb.TableCatalog_Columns_{
+					Columns: &v1pb.TableCatalog_Columns{Columns: columns},
+				},
+			})
+		}
+		filtered.Schemas = append(filtered.Schemas, filteredSchema)
+	}
+	return filtered
+}
+
+func findSchemaCatalog(catalog *v1pb.DatabaseCatalog, schemaName string) *v1pb.SchemaCatalog {
+	for _, schemaCatalog := range catalog.Schemas {
+		if schemaCatalog.Name == schemaName {
+			return schemaCatalog
+		}
+	}
+	return nil
+}
+
+func findTableCatalog(schemaCatalog *v1pb.SchemaCatalog, tableName string) *v1pb.TableCatalog {
+	for _, table := range schemaCatalog.Tables {
+		if table.Name == tableName {
+			return table
+		}
+	}
+	return nil
+}
@@ -0,0 +1,353 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func resourceDatabaseAdoption() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Adopt all databases matching the condition or labels in the instances into the project, with the environment and labels override. The matching databases are resolved on every plan, so newly matched and unmatched databases show in the plan. The databases adopted by this resource but no longer matched are released to the default project.",
+		CreateContext: resourceDatabaseAdoptionUpsert,
+		ReadContext:   resourceDatabaseAdoptionRead,
+		UpdateContext: resourceDatabaseAdoptionUpsert,
+		DeleteContext: resourceDatabaseAdoptionDelete,
+		CustomizeDiff: resolveDatabaseAdoptionDiff,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateDiagFunc: internal.ResourceNameValidation(
+					// project name format
+					fmt.Sprintf("^%s%s$", internal.ProjectNamePrefix, internal.ResourceIDPattern),
+				),
+				Description: "The project full name in projects/{project} format to adopt the databases into.",
+			},
+			"instances": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateDiagFunc: internal.ResourceNameValidation(
+						fmt.Sprintf("^%s%s$", internal.InstanceNamePrefix, internal.ResourceIDPattern),
+					),
+				},
+				Description: "The instances full name to select databases in.",
+			},
+			"condition": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"condition", "match_labels"},
+				Description:  `The CEL expression to match databases with the bytebase_database_group condition attributes, for example resource.database_name.startsWith("tenant_") && resource.environment_id == "prod". The expression is parsed by Bytebase and supports the resource.environment_id, resource.instance_id, resource.database_name and resource.database_labels["key"] attributes with the ==, != and in operators, the startsWith, endsWith, contains and matches functions, and the &&, || and ! logic. Other expressions fail the plan. The database with a missing label doesn't match the label condition. The resource.environment_id cannot be used with the environment override, otherwise the adopted databases no longer match.`,
+			},
+			"match_labels": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				AtLeastOneOf: []string{"condition", "match_labels"},
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Description:  "Match databases with all the labels.",
+			},
+			"environment": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateDiagFunc: internal.ResourceNameValidation(
+					// environment name format
+					fmt.Sprintf("^%s%s$", internal.EnvironmentNamePrefix, internal.ResourceIDPattern),
+				),
+				Description: "The environment full name to override for the adopted databases. The databases follow the instance environment if not set.",
+			},
+			"labels": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The labels to set on the adopted databases. Other labels on the databases are kept.",
+			},
+			"batch_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      batchSize,
+				ValidateFunc: validation.IntBetween(1, 1000),
+				Description:  "The count of databases to assign in a single batch request.",
+			},
+			"matched_databases": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The database full names matching the condition and labels with the override applied.",
+			},
+			"adopted_databases": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The database full names adopted into the project by this resource. The databases no longer matched are released to the default project in the next apply.",
+			},
+		},
+	}
+}
+
+// getDatabaseAdoptionSelector converts the adoption config to the database selector config.
+func getDatabaseAdoptionSelector(d interface {
+	Get(string) interface{}
+}) map[string]interface{} {
+	return map[string]interface{}{
+		"instances":   d.Get("instances"),
+		"environment": "",
+		"engines":     nil,
+		"query":       "",
+		"labels":      d.Get("match_labels"),
+		// The condition uses the database group CEL attributes, it's evaluated in listAdoptionDatabases.
+		"filter": "",
+	}
+}
+
+// listAdoptionDatabases lists the databases in the instances matching the labels and condition.
+func listAdoptionDatabases(ctx context.Context, client api.Client, d interface {
+	Get(string) interface{}
+}) ([]*v1pb.Database, error) {
+	databases, err := listDatabasesBySelector(ctx, client, getDatabaseAdoptionSelector(d))
+	if err != nil {
+		return nil, err
+	}
+	condition := d.Get("condition").(string)
+	if condition == "" {
+		return databases, nil
+	}
+	expr, err := parseDatabaseCondition(ctx, client, condition)
+	if err != nil {
+		return nil, err
+	}
+	// The environment override changes the database environment, so the condition on it would never converge.
+	if d.Get("environment").(string) != "" && conditionReferences(expr, "environment_id") {
+		return nil, errors.New("the condition cannot use the resource.environment_id with the environment override, use the instances to select databases in the environment instead")
+	}
+	matched := []*v1pb.Database{}
+	for _, database := range databases {
+		r, err := newDatabaseConditionResource(database)
+		if err != nil {
+			return nil, err
+		}
+		ok, err := matchDatabaseCondition(expr, r)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to match the condition %q for database %s", condition, database.Name)
+		}
+		if ok {
+			matched = append(matched, database)
+		}
+	}
+	return matched, nil
+}
+
+func resolveDatabaseAdoptionDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	for _, key := range []string{"instances", "condition", "match_labels"} {
+		if !diff.NewValueKnown(key) {
+			return diff.SetNewComputed("matched_databases")
+		}
+	}
+
+	databases, err := listAdoptionDatabases(ctx, m.(api.Client), diff)
+	if err != nil {
+		return err
+	}
+	databaseNames := []string{}
+	for _, database := range databases {
+		databaseNames = append(databaseNames, database.Name)
+	}
+	matched := convertToStringSet(databaseNames)
+	oldMatched, _ := diff.Get("matched_databases").(*schema.Set)
+	oldAdopted, _ := diff.Get("adopted_databases").(*schema.Set)
+	// Plan the change if the matched databases change, or any adopted database needs to be released.
+	if oldMatched != nil && oldMatched.Equal(matched) && (oldAdopted == nil || oldAdopted.Difference(matched).Len() == 0) {
+		return nil
+	}
+	if err := diff.SetNew("matched_databases", matched); err != nil {
+		return err
+	}
+	return diff.SetNewComputed("adopted_databases")
+}
+
+func resourceDatabaseAdoptionUpsert(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	projectName := d.Get("project").(string)
+
+	databases, err := listAdoptionDatabases(ctx, c, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	matched := []string{}
+	for _, database := range databases {
+		matched = append(matched, database.Name)
+	}
+
+	// Release the databases previously adopted by this resource but no longer matched.
+	// The matched_databases in state drops the drifted databases, so the adopted_databases is also checked.
+	oldMatched, _ := d.GetChange("matched_databases")
+	oldAdopted, _ := d.GetChange("adopted_databases")
+	unmatched := []string{}
+	for _, raw := range oldMatched.(*schema.Set).Union(oldAdopted.(*schema.Set)).List() {
+		if databaseName := raw.(string); !slices.Contains(matched, databaseName) {
+			unmatched = append(unmatched, databaseName)
+		}
+	}
+
+	size := d.Get("batch_size").(int)
+	assigned, diags := batchAssignDatabases(ctx, c, matched, projectName, size)
+	released, releaseDiags := batchAssignDatabases(ctx, c, unmatched, c.GetDefaultProjectName(), size)
+	diags = append(diags, releaseDiags...)
+
+	environment := d.Get("environment").(string)
+	labels := convertToStringMap(d.Get("labels").(map[string]interface{}))
+	adopted := []string{}
+	for _, database := range databases {
+		if !slices.Contains(assigned, database.Name) {
+			continue
+		}
+		patch, updateMasks := getDatabaseAdoptionPatch(database, environment, labels)
+		if len(updateMasks) == 0 {
+			adopted = append(adopted, database.Name)
+			continue
+		}
+		if _, err := c.UpdateDatabase(ctx, patch, updateMasks); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to update database",
+				Detail:   fmt.Sprintf("Update %v for database %s failed, error: %v", updateMasks, database.Name, err),
+			})
+			continue
+		}
+		adopted = append(adopted, database.Name)
+	}
+	tflog.Debug(ctx, "[adopt databases]", map[string]interface{}{
+		"matched":   len(matched),
+		"unmatched": len(unmatched),
+		"adopted":   len(adopted),
+	})
+
+	// Keep tracking the databases failed to release, so they're released in the next apply.
+	adoptedDatabases := slices.Clone(assigned)
+	for _, databaseName := range unmatched {
+		if !slices.Contains(released, databaseName) {
+			adoptedDatabases = append(adoptedDatabases, databaseName)
+		}
+	}
+
+	d.SetId(projectName)
+	if err := d.Set("matched_databases", adopted); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if err := d.Set("adopted_databases", adoptedDatabases); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceDatabaseAdoptionRead(ctx, d, m)...)
+}
+
+// getDatabaseAdoptionPatch returns the database patch and update masks to apply the environment and labels override.
+// The update masks are empty if the database already matches the override.
+func getDatabaseAdoptionPatch(database *v1pb.Database, environment string, labels map[string]string) (*v1pb.Database, []string) {
+	patch := &v1pb.Database{
+		Name: database.Name,
+	}
+	updateMasks := []string{}
+
+	if environment != "" && database.GetEnvironment() != environment {
+		patch.Environment = &environment
+		updateMasks = append(updateMasks, "environment")
+	}
+
+	mergedLabels := maps.Clone(database.Labels)
+	if mergedLabels == nil {
+		mergedLabels = map[string]string{}
+	}
+	for key, value := range labels {
+		if v, ok := mergedLabels[key]; ok && v == value {
+			continue
+		}
+		mergedLabels[key] = value
+		patch.Labels = mergedLabels
+	}
+	if patch.Labels != nil {
+		updateMasks = append(updateMasks, "labels")
+	}
+
+	return patch, updateMasks
+}
+
+func resourceDatabaseAdoptionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	projectName := d.Id()
+
+	databases, err := c.ListDatabase(ctx, projectName, &api.DatabaseFilter{}, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	databaseMap := map[string]*v1pb.Database{}
+	for _, database := range databases {
+		databaseMap[database.Name] = database
+	}
+
+	environment := d.Get("environment").(string)
+	labels := convertToStringMap(d.Get("labels").(map[string]interface{}))
+	// Keep the databases still in the project with the override applied, so the drifted databases are adopted again in the next apply.
+	adopted := []string{}
+	for _, raw := range d.Get("matched_databases").(*schema.Set).List() {
+		database, ok := databaseMap[raw.(string)]
+		if !ok {
+			continue
+		}
+		if _, updateMasks := getDatabaseAdoptionPatch(database, environment, labels); len(updateMasks) > 0 {
+			continue
+		}
+		adopted = append(adopted, database.Name)
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.Errorf("cannot set project: %s", err.Error())
+	}
+	if err := d.Set("matched_databases", adopted); err != nil {
+		return diag.Errorf("cannot set matched_databases: %s", err.Error())
+	}
+	// Keep the adopted databases still in the project, the databases moved out are no longer managed by this resource.
+	adoptedDatabases := []string{}
+	for _, raw := range d.Get("adopted_databases").(*schema.Set).List() {
+		if _, ok := databaseMap[raw.(string)]; ok {
+			adoptedDatabases = append(adoptedDatabases, raw.(string))
+		}
+	}
+	if err := d.Set("adopted_databases", adoptedDatabases); err != nil {
+		return diag.Errorf("cannot set adopted_databases: %s", err.Error())
+	}
+	return nil
+}
+
+func resourceDatabaseAdoptionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+
+	released := []string{}
+	for _, raw := range d.Get("matched_databases").(*schema.Set).Union(d.Get("adopted_databases").(*schema.Set)).List() {
+		released = append(released, raw.(string))
+	}
+	if _, diags := batchAssignDatabases(ctx, c, released, c.GetDefaultProjectName(), d.Get("batch_size").(int)); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,220 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func TestAccDatabaseAdoption(t *testing.T) {
+	identifier := "adoption"
+	resourceName := fmt.Sprintf("bytebase_database_adoption.%s", identifier)
+	instanceID := "adoption-instance"
+
+	base := fmt.Sprintf(`
+%s
+
+%s
+`,
+		testAccCheckInstanceResource("adoption_instance", instanceID, "adoption instance", "POSTGRES", "environments/test"),
+		testAccCheckProjectResource("adoption_project", "adoption-project", "adoption project"),
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckProjectDestroy,
+		Steps: []resource.TestStep{
+			// adopt databases by labels with the override
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "bytebase_database_adoption" "%s" {
+	project      = bytebase_project.adoption_project.name
+	instances    = [bytebase_instance.adoption_instance.name]
+	match_labels = {
+		"bb.environment" = "test"
+	}
+	environment = "environments/prod"
+	labels = {
+		team = "dba"
+	}
+}
+
+data "bytebase_database" "adopted" {
+	name = "${bytebase_instance.adoption_instance.name}/databases/test-database"
+	depends_on = [bytebase_database_adoption.%s]
+}
+`, base, identifier, identifier),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "matched_databases.#", "4"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "matched_databases.*", fmt.Sprintf("instances/%s/databases/test-database", instanceID)),
+					resource.TestCheckResourceAttr("data.bytebase_database.adopted", "project", "projects/adoption-project"),
+					resource.TestCheckResourceAttr("data.bytebase_database.adopted", "environment", "environments/prod"),
+					resource.TestCheckResourceAttr("data.bytebase_database.adopted", "labels.team", "dba"),
+					resource.TestCheckResourceAttr("data.bytebase_database.adopted", "labels.bb.environment", "test"),
+				),
+			},
+			// the unmatched databases are released
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "bytebase_database_adoption" "%s" {
+	project      = bytebase_project.adoption_project.name
+	instances    = [bytebase_instance.adoption_instance.name]
+	match_labels = {
+		"team" = "dba"
+		"bb.environment" = "none"
+	}
+}
+`, base, identifier),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "matched_databases.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatabaseAdoption_Condition(t *testing.T) {
+	identifier := "adoption_condition"
+	resourceName := fmt.Sprintf("bytebase_database_adoption.%s", identifier)
+	instanceID := "adoption-condition-instance"
+	driftedDatabase := fmt.Sprintf("instances/%s/databases/test-database-labels", instanceID)
+
+	base := fmt.Sprintf(`
+%s
+
+%s
+`,
+		testAccCheckInstanceResource("adoption_condition_instance", instanceID, "adoption condition instance", "POSTGRES", "environments/test"),
+		testAccCheckProjectResource("adoption_condition_project", "adoption-condition-project", "adoption condition project"),
+	)
+	getConfigWithEnvironment := func(condition, environment string) string {
+		environmentConfig := ""
+		if environment != "" {
+			environmentConfig = fmt.Sprintf("environment = %q", environment)
+		}
+		return fmt.Sprintf(`
+%s
+
+resource "bytebase_database_adoption" "%s" {
+	project   = bytebase_project.adoption_condition_project.name
+	instances = [bytebase_instance.adoption_condition_instance.name]
+	condition = %q
+	%s
+	labels = {
+		team = "dba"
+	}
+}
+
+data "bytebase_database" "released" {
+	name = "%s"
+	depends_on = [bytebase_database_adoption.%s]
+}
+`, base, identifier, condition, environmentConfig, driftedDatabase, identifier)
+	}
+	getConfig := func(condition string) string {
+		return getConfigWithEnvironment(condition, "")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckProjectDestroy,
+		Steps: []resource.TestStep{
+			// adopt databases by the database group condition
+			{
+				Config: getConfig(`resource.database_name.startsWith("test-database") && resource.environment_id == "test"`),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "matched_databases.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "adopted_databases.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "adopted_databases.*", driftedDatabase),
+					resource.TestCheckResourceAttr("data.bytebase_database.released", "project", "projects/adoption-condition-project"),
+				),
+			},
+			// the drifted database is released after it's no longer matched
+			{
+				PreConfig: func() {
+					c, ok := testAccProvider.Meta().(api.Client)
+					if !ok {
+						t.Fatal("cannot get the api client")
+					}
+					if _, err := c.UpdateDatabase(context.Background(), &v1pb.Database{
+						Name:   driftedDatabase,
+						Labels: map[string]string{"team": "app"},
+					}, []string{"labels"}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: getConfig(`resource.database_name == "test-database"`),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "matched_databases.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "adopted_databases.#", "1"),
+					resource.TestCheckResourceAttr("data.bytebase_database.released", "project", fmt.Sprintf("%sdefault-%s", internal.ProjectNamePrefix, internal.MockWorkspaceID)),
+				),
+			},
+			// the unsupported condition fails the plan
+			{
+				Config:      getConfig(`resource.engine == "POSTGRES"`),
+				ExpectError: regexp.MustCompile("unsupported attribute resource.engine"),
+			},
+			// the environment override cannot be used with the environment condition
+			{
+				Config:      getConfigWithEnvironment(`resource.environment_id == "test"`, "environments/prod"),
+				ExpectError: regexp.MustCompile("cannot use the resource.environment_id with the environment override"),
+			},
+		},
+	})
+}
+
+func TestGetDatabaseAdoptionPatch(t *testing.T) {
+	environment := "environments/prod"
+	database := &v1pb.Database{
+		Name:        "instances/adoption/databases/db",
+		Environment: &environment,
+		Labels: map[string]string{
+			"team":   "dba",
+			"region": "us",
+		},
+	}
+
+	if _, updateMasks := getDatabaseAdoptionPatch(database, environment, map[string]string{"team": "dba"}); len(updateMasks) != 0 {
+		t.Fatalf("getDatabaseAdoptionPatch() update masks = %v, want no update", updateMasks)
+	}
+
+	patch, updateMasks := getDatabaseAdoptionPatch(database, "environments/test", map[string]string{"team": "app"})
+	if !slices.Equal(updateMasks, []string{"environment", "labels"}) {
+		t.Fatalf("getDatabaseAdoptionPatch() update masks = %v, want [environment labels]", updateMasks)
+	}
+	if got, want := patch.GetEnvironment(), "environments/test"; got != want {
+		t.Fatalf("patch environment = %q, want %q", got, want)
+	}
+	if got, want := patch.Labels["team"], "app"; got != want {
+		t.Fatalf("patch label team = %q, want %q", got, want)
+	}
+	if got, want := patch.Labels["region"], "us"; got != want {
+		t.Fatalf("patch label region = %q, want %q", got, want)
+	}
+	if got, want := database.Labels["team"], "dba"; got != want {
+		t.Fatalf("database label team = %q after patch, want %q unchanged", got, want)
+	}
+}
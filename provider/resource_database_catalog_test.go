@@ -176,3 +176,79 @@ func TestFlattenDatabaseCatalog_ColumnsPathUnchanged(t *testing.T) {
 		t.Fatalf("expected 1 column, got %d", len(cols))
 	}
 }
+
+func TestMergeDatabaseCatalog_KeepsUndeclared(t *testing.T) {
+	catalog := &v1pb.DatabaseCatalog{
+		Name: "instances/x/databases/y/catalog",
+		Schemas: []*v1pb.SchemaCatalog{{
+			Name: "public",
+			Tables: []*v1pb.TableCatalog{
+				{
+					Name:           "users",
+					Classification: "1-1",
+					Kind: &v1pb.TableCatalog_Columns_{
+						Columns: &v1pb.TableCatalog_Columns{
+							Columns: []*v1pb.ColumnCatalog{
+								{Name: "email", SemanticType: "email-mask"},
+								{Name: "phone", Classification: "1-2"},
+							},
+						},
+					},
+				},
+				{Name: "orders", Classification: "2"},
+			},
+		}},
+	}
+	patch := &v1pb.DatabaseCatalog{
+		Schemas: []*v1pb.SchemaCatalog{{
+			Name: "public",
+			Tables: []*v1pb.TableCatalog{{
+				Name:           "users",
+				Classification: "1",
+				Kind: &v1pb.TableCatalog_Columns_{
+					Columns: &v1pb.TableCatalog_Columns{
+						Columns: []*v1pb.ColumnCatalog{
+							{Name: "email", SemanticType: "default"},
+							{Name: "name", Classification: "1-3"},
+						},
+					},
+				},
+			}},
+		}},
+	}
+
+	merged := mergeDatabaseCatalog(catalog, patch)
+	if len(merged.Schemas) != 1 || len(merged.Schemas[0].Tables) != 2 {
+		t.Fatalf("expected the undeclared table kept, got %v", merged)
+	}
+	users := findTableCatalogInDatabase(merged, "public", "users")
+	if users.Classification != "1" {
+		t.Errorf("expected table classification 1, got %q", users.Classification)
+	}
+	// The table classification is kept if the patch doesn't declare one.
+	patch.Schemas[0].Tables[0].Classification = ""
+	if got := findTableCatalogInDatabase(mergeDatabaseCatalog(catalog, patch), "public", "users").Classification; got != "1-1" {
+		t.Errorf("expected undeclared table classification kept, got %q", got)
+	}
+	if got := findColumnCatalog(users, "email").GetSemanticType(); got != "default" {
+		t.Errorf("expected declared column patched, got semantic type %q", got)
+	}
+	if got := findColumnCatalog(users, "phone").GetClassification(); got != "1-2" {
+		t.Errorf("expected undeclared column kept, got classification %q", got)
+	}
+	if findColumnCatalog(users, "name") == nil {
+		t.Error("expected declared column added")
+	}
+	// The source catalog must not be modified.
+	if got := findColumnCatalog(findTableCatalogInDatabase(catalog, "public", "users"), "email").GetSemanticType(); got != "email-mask" {
+		t.Errorf("expected source catalog unchanged, got semantic type %q", got)
+	}
+
+	filtered := filterDatabaseCatalog(merged, patch)
+	if len(filtered.Schemas) != 1 || len(filtered.Schemas[0].Tables) != 1 {
+		t.Fatalf("expected only the declared table, got %v", filtered)
+	}
+	if got := len(filtered.Schemas[0].Tables[0].GetColumns().GetColumns()); got != 2 {
+		t.Errorf("expected 2 declared columns, got %d", got)
+	}
+}
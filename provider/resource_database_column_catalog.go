@@ -0,0 +1,247 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func resourceDatabaseColumnCatalog() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The catalog for a single database column. Other columns and tables in the database catalog are left alone, so it can be used together with the changes in the Bytebase UI.",
+		CreateContext: resourceDatabaseColumnCatalogUpsert,
+		ReadContext:   resourceDatabaseColumnCatalogRead,
+		UpdateContext: resourceDatabaseColumnCatalogUpsert,
+		DeleteContext: resourceDatabaseColumnCatalogDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateDiagFunc: internal.ResourceNameValidation(
+					// database name format
+					fmt.Sprintf(`^%s%s/%s\S+$`, internal.InstanceNamePrefix, internal.ResourceIDPattern, internal.DatabaseIDPrefix),
+				),
+				Description: "The database full name in instances/{instance}/databases/{database} format.",
+			},
+			"schema": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+				Description: "The schema name. Leave it empty for the engines without schema, for example MySQL.",
+			},
+			"table": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The table name.",
+			},
+			"column": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The column name.",
+			},
+			"semantic_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The semantic type id.",
+			},
+			"classification": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The classification id.",
+			},
+			"labels": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The column labels.",
+			},
+		},
+	}
+}
+
+// getColumnCatalogID returns the column catalog id in {database}/schemas/{schema}/tables/{table}/columns/{column} format.
+func getColumnCatalogID(databaseName, schemaName, tableName, columnName string) string {
+	return fmt.Sprintf("%s/schemas/%s/tables/%s/columns/%s", databaseName, schemaName, tableName, columnName)
+}
+
+// parseColumnCatalogID parses the column catalog id into the database full name, schema, table and column name.
+func parseColumnCatalogID(id string) (string, string, string, string, error) {
+	databaseName, rest, ok := strings.Cut(id, "/schemas/")
+	if !ok {
+		return "", "", "", "", errors.Errorf("invalid column catalog id %s, expect {database}/schemas/{schema}/tables/{table}/columns/{column}", id)
+	}
+	schemaName, rest, ok := strings.Cut(rest, "/tables/")
+	if !ok {
+		return "", "", "", "", errors.Errorf("invalid column catalog id %s, expect {database}/schemas/{schema}/tables/{table}/columns/{column}", id)
+	}
+	tableName, columnName, ok := strings.Cut(rest, "/columns/")
+	if !ok || tableName == "" || columnName == "" {
+		return "", "", "", "", errors.Errorf("invalid column catalog id %s, expect {database}/schemas/{schema}/tables/{table}/columns/{column}", id)
+	}
+	return databaseName, schemaName, tableName, columnName, nil
+}
+
+func resourceDatabaseColumnCatalogUpsert(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	databaseName := d.Get("database").(string)
+	schemaName := d.Get("schema").(string)
+	tableName := d.Get("table").(string)
+	columnName := d.Get("column").(string)
+
+	patch := &v1pb.DatabaseCatalog{
+		Name: fmt.Sprintf("%s%s", databaseName, internal.DatabaseCatalogNameSuffix),
+		Schemas: []*v1pb.SchemaCatalog{
+			{
+				Name: schemaName,
+				Tables: []*v1pb.TableCatalog{
+					{
+						Name: tableName,
+						Kind: &v1pb.TableCatalog_Columns_{
+							Columns: &v1pb.TableCatalog_Columns{
+								Columns: []*v1pb.ColumnCatalog{
+									{
+										Name:           columnName,
+										SemanticType:   d.Get("semantic_type").(string),
+										Classification: d.Get("classification").(string),
+										Labels:         convertToStringMap(d.Get("labels").(map[string]interface{})),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	catalogMutex.Lock()
+	defer catalogMutex.Unlock()
+
+	current, err := c.GetDatabaseCatalog(ctx, databaseName)
+	if err != nil {
+		return diag.Errorf("failed to get catalog for database %s with error: %v", databaseName, err.Error())
+	}
+	merged := mergeDatabaseCatalog(current, patch)
+	merged.Name = patch.Name
+	if _, err := c.UpdateDatabaseCatalog(ctx, merged); err != nil {
+		return diag.Errorf("failed to update catalog for column %s with error: %v", columnName, err.Error())
+	}
+
+	d.SetId(getColumnCatalogID(databaseName, schemaName, tableName, columnName))
+	return resourceDatabaseColumnCatalogRead(ctx, d, m)
+}
+
+func resourceDatabaseColumnCatalogRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	databaseName, schemaName, tableName, columnName, err := parseColumnCatalogID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	catalog, err := c.GetDatabaseCatalog(ctx, databaseName)
+	if err != nil {
+		return diag.Errorf("failed to get catalog for database %s with error: %v", databaseName, err.Error())
+	}
+	column := findColumnCatalog(findTableCatalogInDatabase(catalog, schemaName, tableName), columnName)
+	if column == nil {
+		tflog.Warn(ctx, fmt.Sprintf("column catalog %s not found, remove it from the state", d.Id()))
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("database", databaseName); err != nil {
+		return diag.Errorf("cannot set database for column catalog: %s", err.Error())
+	}
+	if err := d.Set("schema", schemaName); err != nil {
+		return diag.Errorf("cannot set schema for column catalog: %s", err.Error())
+	}
+	if err := d.Set("table", tableName); err != nil {
+		return diag.Errorf("cannot set table for column catalog: %s", err.Error())
+	}
+	if err := d.Set("column", columnName); err != nil {
+		return diag.Errorf("cannot set column for column catalog: %s", err.Error())
+	}
+	if err := d.Set("semantic_type", column.SemanticType); err != nil {
+		return diag.Errorf("cannot set semantic_type for column catalog: %s", err.Error())
+	}
+	if err := d.Set("classification", column.Classification); err != nil {
+		return diag.Errorf("cannot set classification for column catalog: %s", err.Error())
+	}
+	if err := d.Set("labels", column.Labels); err != nil {
+		return diag.Errorf("cannot set labels for column catalog: %s", err.Error())
+	}
+	return nil
+}
+
+func resourceDatabaseColumnCatalogDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	databaseName, schemaName, tableName, columnName, err := parseColumnCatalogID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	catalogMutex.Lock()
+	defer catalogMutex.Unlock()
+
+	catalog, err := c.GetDatabaseCatalog(ctx, databaseName)
+	if err != nil {
+		return diag.Errorf("failed to get catalog for database %s with error: %v", databaseName, err.Error())
+	}
+	table := findTableCatalogInDatabase(catalog, schemaName, tableName)
+	if findColumnCatalog(table, columnName) == nil {
+		d.SetId("")
+		return nil
+	}
+
+	updated := proto.Clone(catalog).(*v1pb.DatabaseCatalog)
+	updated.Name = fmt.Sprintf("%s%s", databaseName, internal.DatabaseCatalogNameSuffix)
+	columns := findTableCatalogInDatabase(updated, schemaName, tableName).GetColumns()
+	columns.Columns = slices.DeleteFunc(columns.Columns, func(column *v1pb.ColumnCatalog) bool {
+		return column.Name == columnName
+	})
+	if _, err := c.UpdateDatabaseCatalog(ctx, updated); err != nil {
+		return diag.Errorf("failed to delete catalog for column %s with error: %v", columnName, err.Error())
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func findTableCatalogInDatabase(catalog *v1pb.DatabaseCatalog, schemaName, tableName string) *v1pb.TableCatalog {
+	schemaCatalog := findSchemaCatalog(catalog, schemaName)
+	if schemaCatalog == nil {
+		return nil
+	}
+	return findTableCatalog(schemaCatalog, tableName)
+}
+
+func findColumnCatalog(table *v1pb.TableCatalog, columnName string) *v1pb.ColumnCatalog {
+	for _, column := range table.GetColumns().GetColumns() {
+		if column.Name == columnName {
+			return column
+		}
+	}
+	return nil
+}
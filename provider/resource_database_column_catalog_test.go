@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func TestAccDatabaseColumnCatalog(t *testing.T) {
+	instanceID := "column-catalog-instance"
+	databaseName := fmt.Sprintf("instances/%s/databases/test-database", instanceID)
+	resourceName := "bytebase_database_column_catalog.email"
+
+	base := testAccCheckInstanceResource("column_catalog_instance", instanceID, "column catalog instance", "POSTGRES", "environments/test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "bytebase_database_column_catalog" "email" {
+	database       = "${bytebase_instance.column_catalog_instance.name}/databases/test-database"
+	schema         = "public"
+	table          = "users"
+	column         = "email"
+	semantic_type  = "email-mask"
+	classification = "1-1"
+}
+
+resource "bytebase_database_column_catalog" "phone" {
+	database       = "${bytebase_instance.column_catalog_instance.name}/databases/test-database"
+	schema         = "public"
+	table          = "users"
+	column         = "phone"
+	classification = "1-2"
+	depends_on     = [bytebase_database_column_catalog.email]
+}
+`, base),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("%s/schemas/public/tables/users/columns/email", databaseName)),
+					resource.TestCheckResourceAttr(resourceName, "semantic_type", "email-mask"),
+					resource.TestCheckResourceAttr("bytebase_database_column_catalog.phone", "classification", "1-2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestParseColumnCatalogID(t *testing.T) {
+	databaseName, schemaName, tableName, columnName, err := parseColumnCatalogID("instances/i/databases/db/schemas//tables/users/columns/email")
+	if err != nil {
+		t.Fatalf("parseColumnCatalogID() error = %v", err)
+	}
+	if databaseName != "instances/i/databases/db" || schemaName != "" || tableName != "users" || columnName != "email" {
+		t.Fatalf("parseColumnCatalogID() = %q, %q, %q, %q", databaseName, schemaName, tableName, columnName)
+	}
+	if _, _, _, _, err := parseColumnCatalogID("instances/i/databases/db/tables/users"); err == nil {
+		t.Fatal("parseColumnCatalogID() for invalid id returned no error")
+	}
+}
@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+// databaseLabelMutex serializes the read-modify-write on database labels.
+var databaseLabelMutex sync.Mutex
+
+func resourceDatabaseLabel() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage the database labels without touching the labels written by others. Leave the labels unset in the bytebase_database resource if use this resource.",
+		CreateContext: resourceDatabaseLabelUpsert,
+		ReadContext:   resourceDatabaseLabelRead,
+		UpdateContext: resourceDatabaseLabelUpsert,
+		DeleteContext: resourceDatabaseLabelDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDatabaseLabelImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateDiagFunc: internal.ResourceNameValidation(
+					// database name format
+					fmt.Sprintf(`^%s%s/%s\S+$`, internal.InstanceNamePrefix, internal.ResourceIDPattern, internal.DatabaseIDPrefix),
+				),
+				Description: "The database full name in instances/{instance}/databases/{database} format.",
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"key", "labels"},
+				RequiredWith: []string{"value"},
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The label key. Conflicts with labels.",
+			},
+			"value": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"key"},
+				Description:  "The label value.",
+			},
+			"labels": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ExactlyOneOf: []string{"key", "labels"},
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Description:  "The labels to manage. Conflicts with key.",
+			},
+			"ignore_unmanaged_keys": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Only for labels. Keep the labels not in the labels map. Set it to false to remove the other labels on the database.",
+			},
+		},
+	}
+}
+
+// getDatabaseLabelID returns the database label id in {database}/labels/{key} format, or {database}/labels for the labels map.
+func getDatabaseLabelID(databaseName, key string) string {
+	if key == "" {
+		return fmt.Sprintf("%s/labels", databaseName)
+	}
+	return fmt.Sprintf("%s/labels/%s", databaseName, key)
+}
+
+// parseDatabaseLabelID parses the database label id into the database full name and the label key.
+// The id is split by the segment position instead of the "labels" text, so both the database and the key can be named labels.
+func parseDatabaseLabelID(id string) (string, string, error) {
+	// instances/{instance}/databases/{database}/labels[/{key}]
+	parts := strings.SplitN(id, "/", 6)
+	if len(parts) < 5 ||
+		fmt.Sprintf("%s/", parts[0]) != internal.InstanceNamePrefix || parts[1] == "" ||
+		fmt.Sprintf("%s/", parts[2]) != internal.DatabaseIDPrefix || parts[3] == "" ||
+		parts[4] != "labels" ||
+		(len(parts) == 6 && parts[5] == "") {
+		return "", "", errors.Errorf("invalid database label id %s, expect {database}/labels/{key} or {database}/labels", id)
+	}
+	databaseName := strings.Join(parts[:4], "/")
+	if len(parts) == 5 {
+		return databaseName, "", nil
+	}
+	return databaseName, parts[5], nil
+}
+
+// getManagedDatabaseLabels returns the labels managed by the resource.
+func getManagedDatabaseLabels(key, value string, labels map[string]interface{}) map[string]string {
+	if key != "" {
+		return map[string]string{key: value}
+	}
+	return convertToStringMap(labels)
+}
+
+func resourceDatabaseLabelUpsert(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	databaseName := d.Get("database").(string)
+	key := d.Get("key").(string)
+
+	oldValue, _ := d.GetChange("value")
+	oldLabels, _ := d.GetChange("labels")
+	oldManaged := map[string]string{}
+	if d.Id() != "" {
+		oldManaged = getManagedDatabaseLabels(key, oldValue.(string), oldLabels.(map[string]interface{}))
+	}
+	managed := getManagedDatabaseLabels(key, d.Get("value").(string), d.Get("labels").(map[string]interface{}))
+	authoritative := key == "" && !d.Get("ignore_unmanaged_keys").(bool)
+
+	if err := patchDatabaseLabels(ctx, c, databaseName, func(labels map[string]string) map[string]string {
+		if authoritative {
+			return maps.Clone(managed)
+		}
+		for k := range oldManaged {
+			if _, ok := managed[k]; !ok {
+				delete(labels, k)
+			}
+		}
+		maps.Copy(labels, managed)
+		return labels
+	}); err != nil {
+		return diag.Errorf("failed to update labels for database %s with error: %v", databaseName, err.Error())
+	}
+
+	d.SetId(getDatabaseLabelID(databaseName, key))
+	return resourceDatabaseLabelRead(ctx, d, m)
+}
+
+// patchDatabaseLabels reads the latest database labels, applies the patch and updates the labels.
+// The labels are not updated if the patch makes no change.
+func patchDatabaseLabels(ctx context.Context, client api.Client, databaseName string, patch func(labels map[string]string) map[string]string) error {
+	databaseLabelMutex.Lock()
+	defer databaseLabelMutex.Unlock()
+
+	database, err := client.GetDatabase(ctx, databaseName)
+	if err != nil {
+		return err
+	}
+	labels := maps.Clone(database.Labels)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels = patch(labels)
+	if maps.Equal(labels, database.Labels) {
+		return nil
+	}
+
+	_, err = client.UpdateDatabase(ctx, &v1pb.Database{
+		Name:   databaseName,
+		Labels: labels,
+	}, []string{"labels"})
+	return err
+}
+
+func resourceDatabaseLabelRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	databaseName, key, err := parseDatabaseLabelID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	database, err := c.GetDatabase(ctx, databaseName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("database", databaseName); err != nil {
+		return diag.Errorf("cannot set database: %s", err.Error())
+	}
+	if key != "" {
+		value, ok := database.Labels[key]
+		if !ok {
+			tflog.Warn(ctx, fmt.Sprintf("label %s not found in database %s, remove it from the state", key, databaseName))
+			d.SetId("")
+			return nil
+		}
+		if err := d.Set("key", key); err != nil {
+			return diag.Errorf("cannot set key: %s", err.Error())
+		}
+		if err := d.Set("value", value); err != nil {
+			return diag.Errorf("cannot set value: %s", err.Error())
+		}
+		return nil
+	}
+
+	labels := map[string]string{}
+	if d.Get("ignore_unmanaged_keys").(bool) {
+		for k := range d.Get("labels").(map[string]interface{}) {
+			if v, ok := database.Labels[k]; ok {
+				labels[k] = v
+			}
+		}
+	} else {
+		maps.Copy(labels, database.Labels)
+	}
+	if err := d.Set("labels", labels); err != nil {
+		return diag.Errorf("cannot set labels: %s", err.Error())
+	}
+	return nil
+}
+
+func resourceDatabaseLabelDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	databaseName, key, err := parseDatabaseLabelID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	managed := getManagedDatabaseLabels(key, d.Get("value").(string), d.Get("labels").(map[string]interface{}))
+	if err := patchDatabaseLabels(ctx, c, databaseName, func(labels map[string]string) map[string]string {
+		for k := range managed {
+			delete(labels, k)
+		}
+		return labels
+	}); err != nil {
+		return diag.Errorf("failed to delete labels for database %s with error: %v", databaseName, err.Error())
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceDatabaseLabelImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	databaseName, key, err := parseDatabaseLabelID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if key != "" {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	// Import all the labels of the database for the labels map.
+	database, err := m.(api.Client).GetDatabase(ctx, databaseName)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Set("labels", database.Labels); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func TestAccDatabaseLabel(t *testing.T) {
+	instanceID := "database-label-instance"
+	databaseName := fmt.Sprintf("instances/%s/databases/test-database", instanceID)
+
+	base := testAccCheckInstanceResource("database_label_instance", instanceID, "database label instance", "POSTGRES", "environments/test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// manage a single key and a map on the same database
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "bytebase_database_label" "team" {
+	database = "${bytebase_instance.database_label_instance.name}/databases/test-database"
+	key      = "team"
+	value    = "dba"
+}
+
+resource "bytebase_database_label" "tags" {
+	database = "${bytebase_instance.database_label_instance.name}/databases/test-database"
+	labels = {
+		tier   = "gold"
+		region = "us"
+	}
+	depends_on = [bytebase_database_label.team]
+}
+
+data "bytebase_database" "labeled" {
+	name       = "${bytebase_instance.database_label_instance.name}/databases/test-database"
+	depends_on = [bytebase_database_label.tags]
+}
+`, base),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists("bytebase_database_label.team"),
+					resource.TestCheckResourceAttr("bytebase_database_label.team", "id", fmt.Sprintf("%s/labels/team", databaseName)),
+					resource.TestCheckResourceAttr("bytebase_database_label.tags", "labels.%", "2"),
+					// the existing bb.environment label is kept
+					resource.TestCheckResourceAttr("data.bytebase_database.labeled", "labels.%", "4"),
+					resource.TestCheckResourceAttr("data.bytebase_database.labeled", "labels.bb.environment", "test"),
+				),
+			},
+			// remove a key from the map
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "bytebase_database_label" "team" {
+	database = "${bytebase_instance.database_label_instance.name}/databases/test-database"
+	key      = "team"
+	value    = "dba"
+}
+
+resource "bytebase_database_label" "tags" {
+	database = "${bytebase_instance.database_label_instance.name}/databases/test-database"
+	labels = {
+		tier = "silver"
+	}
+	depends_on = [bytebase_database_label.team]
+}
+
+data "bytebase_database" "labeled" {
+	name       = "${bytebase_instance.database_label_instance.name}/databases/test-database"
+	depends_on = [bytebase_database_label.tags]
+}
+`, base),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bytebase_database_label.tags", "labels.tier", "silver"),
+					resource.TestCheckResourceAttr("data.bytebase_database.labeled", "labels.%", "3"),
+					resource.TestCheckResourceAttr("data.bytebase_database.labeled", "labels.team", "dba"),
+					resource.TestCheckNoResourceAttr("data.bytebase_database.labeled", "labels.region"),
+				),
+			},
+			{
+				ResourceName:      "bytebase_database_label.team",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestParseDatabaseLabelID(t *testing.T) {
+	tests := []struct {
+		id           string
+		wantDatabase string
+		wantKey      string
+		wantErr      bool
+	}{
+		{id: "instances/i/databases/db/labels/team", wantDatabase: "instances/i/databases/db", wantKey: "team"},
+		{id: "instances/i/databases/db/labels", wantDatabase: "instances/i/databases/db"},
+		{id: "instances/i/databases/db/labels/labels", wantDatabase: "instances/i/databases/db", wantKey: "labels"},
+		{id: "instances/i/databases/labels/labels", wantDatabase: "instances/i/databases/labels"},
+		{id: "instances/i/databases/labels/labels/labels", wantDatabase: "instances/i/databases/labels", wantKey: "labels"},
+		{id: "instances/i/databases/labels/labels/team", wantDatabase: "instances/i/databases/labels", wantKey: "team"},
+		{id: "instances/i/databases/db/labels/bb.team/app", wantDatabase: "instances/i/databases/db", wantKey: "bb.team/app"},
+		{id: "instances/i/databases/db", wantErr: true},
+		{id: "instances/i/databases/db/labels/", wantErr: true},
+		{id: "instances/i/databases/db/tags/team", wantErr: true},
+		{id: "projects/p/databases/db/labels", wantErr: true},
+	}
+	for _, test := range tests {
+		databaseName, key, err := parseDatabaseLabelID(test.id)
+		if (err != nil) != test.wantErr {
+			t.Fatalf("parseDatabaseLabelID(%q) error = %v, want error %v", test.id, err, test.wantErr)
+		}
+		if databaseName != test.wantDatabase || key != test.wantKey {
+			t.Errorf("parseDatabaseLabelID(%q) = %q, %q, want %q, %q", test.id, databaseName, key, test.wantDatabase, test.wantKey)
+		}
+		if !test.wantErr && getDatabaseLabelID(databaseName, key) != test.id {
+			t.Errorf("getDatabaseLabelID(%q, %q) = %q, want %q", databaseName, key, getDatabaseLabelID(databaseName, key), test.id)
+		}
+	}
+}
@@ -8,10 +8,13 @@ import (
 	"testing"
 
 	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+	"connectrpc.com/connect"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/pkg/errors"
 
+	"github.com/bytebase/terraform-provider-bytebase/api"
 	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
 )
 
@@ -164,8 +167,11 @@ func TestResourceDatabaseDeleteMovesDatabaseToWorkspaceDefaultProject(t *testing
 	d.SetId(databaseName)
 
 	diags = resourceDatabaseDelete(ctx, d, meta)
-	if diags.HasError() {
-		t.Fatalf("resourceDatabaseDelete() returned diagnostics: %v", diags)
+	if len(diags) > 0 {
+		t.Fatalf("resourceDatabaseDelete() returned diagnostics: %v, want the drop rollout finished", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("resource id after drop = %q, want empty", d.Id())
 	}
 
 	database, err := meta.(interface {
@@ -179,6 +185,110 @@ func TestResourceDatabaseDeleteMovesDatabaseToWorkspaceDefaultProject(t *testing
 	}
 }
 
+func TestResourceDatabaseCreateIfMissingAndDropOnDestroy(t *testing.T) {
+	ctx := context.Background()
+	meta, diags := internal.MockProviderConfigure(ctx, schema.TestResourceDataRaw(t, NewProvider().Schema, map[string]interface{}{
+		"url":             "http://bytebase.example.com",
+		"service_account": "service@example.com",
+		"service_key":     "secret",
+	}))
+	if diags.HasError() {
+		t.Fatalf("MockProviderConfigure() returned diagnostics: %v", diags)
+	}
+
+	environment := "environments/test"
+	if _, err := meta.(interface {
+		CreateInstance(context.Context, string, *v1pb.Instance) (*v1pb.Instance, error)
+	}).CreateInstance(ctx, "create-if-missing", &v1pb.Instance{
+		Title:       "Test Instance",
+		Engine:      v1pb.Engine_MYSQL,
+		Environment: &environment,
+	}); err != nil {
+		t.Fatalf("CreateInstance() error = %v", err)
+	}
+	getDatabase := meta.(interface {
+		GetDatabase(context.Context, string) (*v1pb.Database, error)
+	}).GetDatabase
+
+	databaseName := "instances/create-if-missing/databases/new-db"
+	d := schema.TestResourceDataRaw(t, resourceDatabase().Schema, map[string]interface{}{
+		"name":              databaseName,
+		"project":           "projects/test-project",
+		"create_if_missing": true,
+		"create_options": []interface{}{
+			map[string]interface{}{
+				"character_set": "utf8mb4",
+				"collation":     "utf8mb4_general_ci",
+			},
+		},
+		"drop_on_destroy":   true,
+		"drop_confirmation": databaseName,
+	})
+
+	diags = resourceDatabaseUpdate(ctx, d, meta)
+	if diags.HasError() {
+		t.Fatalf("resourceDatabaseUpdate() returned diagnostics: %v", diags)
+	}
+	database, err := getDatabase(ctx, databaseName)
+	if err != nil {
+		t.Fatalf("GetDatabase() error = %v", err)
+	}
+	if got, want := database.Project, "projects/test-project"; got != want {
+		t.Fatalf("database project after create = %q, want %q", got, want)
+	}
+
+	diags = resourceDatabaseDelete(ctx, d, meta)
+	if len(diags) > 0 {
+		t.Fatalf("resourceDatabaseDelete() returned diagnostics: %v, want the drop rollout finished", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("resource id after drop = %q, want empty", d.Id())
+	}
+	if _, err := getDatabase(ctx, databaseName); err == nil {
+		t.Fatalf("GetDatabase() after drop returned no error, want database %s dropped", databaseName)
+	}
+}
+
+// permissionDeniedClient fails to get the database with the permission denied error.
+type permissionDeniedClient struct {
+	api.Client
+}
+
+func (permissionDeniedClient) GetDatabase(_ context.Context, databaseName string) (*v1pb.Database, error) {
+	return nil, connect.NewError(connect.CodePermissionDenied, errors.Errorf("cannot get database %s", databaseName))
+}
+
+func TestCreateDatabaseIfMissingReturnsGetError(t *testing.T) {
+	databaseName := "instances/create-if-missing/databases/new-db"
+	d := schema.TestResourceDataRaw(t, resourceDatabase().Schema, map[string]interface{}{
+		"name":              databaseName,
+		"project":           "projects/test-project",
+		"create_if_missing": true,
+	})
+
+	// The embedded client is nil, so creating the database panics.
+	diags := createDatabaseIfMissing(context.Background(), permissionDeniedClient{}, d)
+	if !diags.HasError() {
+		t.Fatalf("createDatabaseIfMissing() returned no error for the permission denied error")
+	}
+	if got := diags[0].Summary; !strings.Contains(got, "permission_denied") {
+		t.Fatalf("createDatabaseIfMissing() error = %q, want the permission denied error", got)
+	}
+}
+
+func TestGetDropDatabaseStatement(t *testing.T) {
+	statement, err := getDropDatabaseStatement(v1pb.Engine_MYSQL, "my`db")
+	if err != nil {
+		t.Fatalf("getDropDatabaseStatement() error = %v", err)
+	}
+	if want := "DROP DATABASE `my``db`;"; statement != want {
+		t.Fatalf("getDropDatabaseStatement() = %q, want %q", statement, want)
+	}
+	if _, err := getDropDatabaseStatement(v1pb.Engine_POSTGRES, "db"); err == nil {
+		t.Fatal("getDropDatabaseStatement() for POSTGRES returned no error")
+	}
+}
+
 func testAccCheckDatabaseResource(identifier, name, project, environment string) string {
 	// Extract instance ID from database name
 	instanceID := ""
@@ -211,8 +321,9 @@ resource "bytebase_environment" "test_env_%s" {
 
 # Create project
 resource "bytebase_project" "test_project_%s" {
-	resource_id = "%s"
-	title       = "Test Project"
+	resource_id         = "%s"
+	title               = "Test Project"
+	deletion_protection = false
 }
 
 # Create instance with a default database
@@ -229,6 +340,8 @@ resource "bytebase_instance" "test_instance_%s" {
 		host     = "127.0.0.1"
 		port     = "5432"
 	}
+
+	deletion_protection = false
 }
 
 # Now manage the database
@@ -274,8 +387,9 @@ resource "bytebase_environment" "test_env_%s" {
 
 # Create project
 resource "bytebase_project" "test_project_%s" {
-	resource_id = "%s"
-	title       = "Test Project"
+	resource_id         = "%s"
+	title               = "Test Project"
+	deletion_protection = false
 }
 
 # Create instance with a default database
@@ -292,6 +406,8 @@ resource "bytebase_instance" "test_instance_%s" {
 		host     = "127.0.0.1"
 		port     = "5432"
 	}
+
+	deletion_protection = false
 }
 
 # Now manage the database with labels
@@ -341,8 +457,9 @@ resource "bytebase_environment" "test_env_%s" {
 
 # Create project
 resource "bytebase_project" "test_project_%s" {
-	resource_id = "%s"
-	title       = "Test Project"
+	resource_id         = "%s"
+	title               = "Test Project"
+	deletion_protection = false
 }
 
 # Create instance with a default database
@@ -359,6 +476,8 @@ resource "bytebase_instance" "test_instance_%s" {
 		host     = "127.0.0.1"
 		port     = "5432"
 	}
+
+	deletion_protection = false
 }
 
 # Now manage the database with updated labels
@@ -469,8 +588,9 @@ resource "bytebase_environment" "env_%s" {
 	order       = 0
 }
 resource "bytebase_project" "proj_%s" {
-	resource_id = "%s"
-	title       = "Test Project"
+	resource_id         = "%s"
+	title               = "Test Project"
+	deletion_protection = false
 }
 resource "bytebase_instance" "inst_%s" {
 	resource_id = "%s"
@@ -485,6 +605,8 @@ resource "bytebase_instance" "inst_%s" {
 		host     = "127.0.0.1"
 		port     = "5432"
 	}
+
+	deletion_protection = false
 }
 resource "bytebase_database" "%s" {
 	name        = "%s"
@@ -70,6 +70,7 @@ func resourceEnvironmentSchema() map[string]*schema.Schema {
 			Optional:    true,
 			Description: "The environment is protected or not.",
 		},
+		"deletion_protection": getDeletionProtectionSchema("environment", false),
 	}
 }
 
@@ -185,6 +186,9 @@ func resourceEnvironmentRead(ctx context.Context, d *schema.ResourceData, m inte
 }
 
 func resourceEnvironmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if diags := checkDeletionProtection(d, "environment"); diags.HasError() {
+		return diags
+	}
 	c := m.(api.Client)
 
 	// Warning or errors can be collected in a slice type
@@ -131,8 +131,9 @@ func testAccCheckProjectIAMPolicyResource(identifier, projectID string) string {
 	return fmt.Sprintf(`
 # Create a project first
 resource "bytebase_project" "test_project_%s" {
-	resource_id = "%s"
-	title       = "Test IAM Project"
+	resource_id         = "%s"
+	title               = "Test IAM Project"
+	deletion_protection = false
 }
 
 # Create a role to use in the IAM policy
@@ -168,8 +169,9 @@ func testAccCheckProjectIAMPolicyResourceWithMultipleBindings(identifier, projec
 	return fmt.Sprintf(`
 # Create a project first
 resource "bytebase_project" "test_project_%s" {
-	resource_id = "%s"
-	title       = "Test IAM Project"
+	resource_id         = "%s"
+	title               = "Test IAM Project"
+	deletion_protection = false
 }
 
 # Create roles to use in the IAM policy
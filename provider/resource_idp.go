@@ -22,6 +22,7 @@ func resourceIdentityProvider() *schema.Resource {
 		CreateContext: resourceIdentityProviderCreate,
 		UpdateContext: resourceIdentityProviderUpdate,
 		DeleteContext: resourceIdentityProviderDelete,
+		CustomizeDiff: validateDeletionProtection("identity provider", "type"),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -55,9 +56,10 @@ func resourceIdentityProvider() *schema.Resource {
 				Description:  "The identity provider type. One of OAUTH2, OIDC, LDAP.",
 				ValidateFunc: validation.StringInSlice([]string{"OAUTH2", "OIDC", "LDAP"}, false),
 			},
-			"oauth2_config": getOAuth2ConfigSchema(),
-			"oidc_config":   getOIDCConfigSchema(),
-			"ldap_config":   getLDAPConfigSchema(),
+			"oauth2_config":       getOAuth2ConfigSchema(),
+			"oidc_config":         getOIDCConfigSchema(),
+			"ldap_config":         getLDAPConfigSchema(),
+			"deletion_protection": getDeletionProtectionSchema("identity provider", false, "type"),
 		},
 	}
 }
@@ -387,6 +389,9 @@ func resourceIdentityProviderUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceIdentityProviderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if diags := checkDeletionProtection(d, "identity provider"); diags.HasError() {
+		return diags
+	}
 	c := m.(api.Client)
 	return internal.ResourceDelete(ctx, d, c.DeleteIdentityProvider)
 }
@@ -4,13 +4,19 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"slices"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
@@ -29,6 +35,14 @@ func resourceInstance() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+		// The connection error is returned alone, so the diagnostic keeps the data_sources attribute path.
+		CustomizeDiff: customdiff.Sequence(
+			validateInstanceConnection,
+			validateAdoptExisting("instance", "resource_id", findExistingInstance),
+		),
 		Schema: map[string]*schema.Schema{
 			"resource_id": {
 				Type:         schema.TypeString,
@@ -98,619 +112,700 @@ func resourceInstance() *schema.Resource {
 				Description: "The last time the instance was synced.",
 			},
 			"roles": getInstanceRolesSchema(),
+			"wait_for_sync": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait for the initial schema sync after the instance is created, until the instance last_sync_time is updated or the sync error is reported on a database synced since then. The wait is limited by the create timeout. Enable it if the discovered databases are used or managed in the same apply.",
+			},
+			"purge_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Permanently delete the instance on destroy instead of the soft delete, so a new instance can be created with the same resource_id. The purged instance cannot be restored.",
+			},
+			"deletion_protection": getDeletionProtectionSchema("instance", true),
+			"adopt_existing":      getAdoptExistingSchema("instance"),
+			"adoption":            getAdoptionSchema("instance"),
+			"validate_connection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Test the connection for each data source during plan when the data sources are created or changed, so the connection errors are reported before apply. Requires the Bytebase server to reach the instance at plan time.",
+			},
 			"data_sources": {
 				Type:        schema.TypeSet,
 				Required:    true,
 				MinItems:    1,
-				Description: "The connection for the instance. You can configure read-only or admin connection account here.",
+				Description: "The connection for the instance. You can configure read-only or admin connection account here. The READ_ONLY data sources not in the set are kept, so they can be managed by the bytebase_instance_data_source resource.",
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"id": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "The unique data source id in this instance.",
-						},
-						"type": {
-							Type:     schema.TypeString,
-							Required: true,
-							ValidateFunc: validation.StringInSlice([]string{
-								v1pb.DataSourceType_ADMIN.String(),
-								v1pb.DataSourceType_READ_ONLY.String(),
-							}, false),
-							Description: "The data source type. Should be ADMIN or READ_ONLY. The READ_ONLY data source requires the instance license.",
-						},
-						"username": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "",
-							Description: "The connection user name used by Bytebase to perform DDL and DML operations.",
-						},
-						"password": {
-							Type:             schema.TypeString,
-							Optional:         true,
-							Sensitive:        true,
-							Computed:         true,
-							DiffSuppressFunc: suppressSensitiveFieldDiff,
-							Description:      "The connection user password used by Bytebase to perform DDL and DML operations.",
-						},
-						"external_secret": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							MaxItems:    1,
-							MinItems:    0,
-							Description: "The external secret to get the database password. Only available when authentication_type is PASSWORD. Requires instance license. Learn more: https://docs.bytebase.com/get-started/connect/overview#secret-manager-integration",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"vault": {
-										Type:        schema.TypeList,
-										Optional:    true,
-										MaxItems:    1,
-										MinItems:    0,
-										Description: "The Valut to get the database password. Reference doc https://developer.hashicorp.com/vault/api-docs/secret/kv/kv-v2",
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"url": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "The Vault URL.",
-												},
-												"token": {
-													Type:        schema.TypeString,
-													Optional:    true,
-													Sensitive:   true,
-													Description: "The root token without TTL. Learn more: https://developer.hashicorp.com/vault/docs/commands/operator/generate-root",
-												},
-												"token_type": {
-													Type:        schema.TypeString,
-													Optional:    true,
-													Description: "How to interpret the token field. PLAIN (the literal token value, default), ENVIRONMENT (the name of an environment variable on the Bytebase server holding the token), or FILE (a path to a file on the Bytebase server holding the token).",
-													ValidateFunc: validation.StringInSlice([]string{
-														v1pb.DataSourceExternalSecret_PLAIN.String(),
-														v1pb.DataSourceExternalSecret_ENVIRONMENT.String(),
-														v1pb.DataSourceExternalSecret_FILE.String(),
-													}, false),
-												},
-												"app_role": {
-													Type:        schema.TypeList,
-													Optional:    true,
-													MaxItems:    1,
-													MinItems:    0,
-													Description: "The Vault app role to get the password.",
-													Elem: &schema.Resource{
-														Schema: map[string]*schema.Schema{
-															"role_id": {
-																Type:        schema.TypeString,
-																Required:    true,
-																Sensitive:   true,
-																Description: "The app role id.",
-															},
-															"secret": {
-																Type:        schema.TypeString,
-																Required:    true,
-																Sensitive:   true,
-																Description: "The secret id for the role without ttl.",
-															},
-															"secret_type": {
-																Type:        schema.TypeString,
-																Required:    true,
-																Description: "The secret id type, can be PLAIN (plain text for the secret) or ENVIRONMENT (envirionment name for the secret).",
-																ValidateFunc: validation.StringInSlice([]string{
-																	v1pb.DataSourceExternalSecret_AppRoleAuthOption_PLAIN.String(),
-																	v1pb.DataSourceExternalSecret_AppRoleAuthOption_ENVIRONMENT.String(),
-																}, false),
-															},
-														},
-													},
-												},
-												"engine_name": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "The name for secret engine.",
-												},
-												"secret_name": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "The secret name in the engine to store the password.",
-												},
-												"password_key_name": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "The key name for the password.",
-												},
+					Schema: getDataSourceSchema(),
+				},
+				Set: dataSourceHash,
+			},
+			"data_source_credentials": getDataSourceCredentialsSchema(),
+			"list_all_databases": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "List all databases in this instance. If false, will only list 500 databases.",
+			},
+			"databases": getDatabasesSchema(true),
+		},
+	}
+}
+
+// getDataSourceSchema returns the schema for the instance data source.
+func getDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The unique data source id in this instance.",
+		},
+		"type": {
+			Type:     schema.TypeString,
+			Required: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				v1pb.DataSourceType_ADMIN.String(),
+				v1pb.DataSourceType_READ_ONLY.String(),
+			}, false),
+			Description: "The data source type. Should be ADMIN or READ_ONLY. The READ_ONLY data source requires the instance license.",
+		},
+		"username": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "The connection user name used by Bytebase to perform DDL and DML operations.",
+		},
+		"password": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Sensitive:        true,
+			Computed:         true,
+			DiffSuppressFunc: suppressSensitiveFieldDiff,
+			Description:      "The connection user password used by Bytebase to perform DDL and DML operations.",
+		},
+		"external_secret": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			MinItems:    0,
+			Description: "The external secret to get the database password. Only available when authentication_type is PASSWORD. Requires instance license. Learn more: https://docs.bytebase.com/get-started/connect/overview#secret-manager-integration",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"vault": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						MinItems:    0,
+						Description: "The Valut to get the database password. Reference doc https://developer.hashicorp.com/vault/api-docs/secret/kv/kv-v2",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"url": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "The Vault URL.",
+								},
+								"token": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Sensitive:   true,
+									Description: "The root token without TTL. Learn more: https://developer.hashicorp.com/vault/docs/commands/operator/generate-root",
+								},
+								"token_type": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Description: "How to interpret the token field. PLAIN (the literal token value, default), ENVIRONMENT (the name of an environment variable on the Bytebase server holding the token), or FILE (a path to a file on the Bytebase server holding the token).",
+									ValidateFunc: validation.StringInSlice([]string{
+										v1pb.DataSourceExternalSecret_PLAIN.String(),
+										v1pb.DataSourceExternalSecret_ENVIRONMENT.String(),
+										v1pb.DataSourceExternalSecret_FILE.String(),
+									}, false),
+								},
+								"app_role": {
+									Type:        schema.TypeList,
+									Optional:    true,
+									MaxItems:    1,
+									MinItems:    0,
+									Description: "The Vault app role to get the password.",
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"role_id": {
+												Type:        schema.TypeString,
+												Required:    true,
+												Sensitive:   true,
+												Description: "The app role id.",
 											},
-										},
-									},
-									"aws_secrets_manager": {
-										Type:        schema.TypeList,
-										Optional:    true,
-										MaxItems:    1,
-										MinItems:    0,
-										Description: "The AWS Secrets Manager to get the database password. Reference doc https://docs.aws.amazon.com/secretsmanager/latest/userguide/intro.html",
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"secret_name": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "The secret name to store the password.",
-												},
-												"password_key_name": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "The key name for the password.",
-												},
+											"secret": {
+												Type:        schema.TypeString,
+												Required:    true,
+												Sensitive:   true,
+												Description: "The secret id for the role without ttl.",
 											},
-										},
-									},
-									"gcp_secret_manager": {
-										Type:        schema.TypeList,
-										Optional:    true,
-										MaxItems:    1,
-										MinItems:    0,
-										Description: "The GCP Secret Manager to get the database password. Reference doc https://cloud.google.com/secret-manager/docs",
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"secret_name": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "The secret name should be like \"projects/{project-id}/secrets/{secret-id}\".",
-												},
+											"secret_type": {
+												Type:        schema.TypeString,
+												Required:    true,
+												Description: "The secret id type, can be PLAIN (plain text for the secret) or ENVIRONMENT (envirionment name for the secret).",
+												ValidateFunc: validation.StringInSlice([]string{
+													v1pb.DataSourceExternalSecret_AppRoleAuthOption_PLAIN.String(),
+													v1pb.DataSourceExternalSecret_AppRoleAuthOption_ENVIRONMENT.String(),
+												}, false),
 											},
 										},
 									},
 								},
-							},
-						},
-						"use_ssl": {
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-							Description: "Enable SSL connection. Required to use SSL certificates.",
-						},
-						"ssl_ca": {
-							Type:             schema.TypeString,
-							Optional:         true,
-							Sensitive:        true,
-							Computed:         true,
-							DiffSuppressFunc: suppressSensitiveFieldDiff,
-							Description:      "The inline PEM CA certificate. Mutually exclusive with ssl_ca_path.",
-						},
-						"ssl_cert": {
-							Type:             schema.TypeString,
-							Optional:         true,
-							Sensitive:        true,
-							Computed:         true,
-							DiffSuppressFunc: suppressSensitiveFieldDiff,
-							Description:      "The inline PEM client certificate. Mutually exclusive with ssl_cert_path.",
-						},
-						"ssl_key": {
-							Type:             schema.TypeString,
-							Optional:         true,
-							Sensitive:        true,
-							Computed:         true,
-							DiffSuppressFunc: suppressSensitiveFieldDiff,
-							Description:      "The inline PEM client private key. Mutually exclusive with ssl_key_path.",
-						},
-						"ssl_ca_path": {
-							Type:             schema.TypeString,
-							Optional:         true,
-							Computed:         true,
-							DiffSuppressFunc: suppressSensitiveFieldDiff,
-							Description:      "Absolute filesystem path to the CA certificate on the Bytebase server. Mutually exclusive with ssl_ca.",
-						},
-						"ssl_cert_path": {
-							Type:             schema.TypeString,
-							Optional:         true,
-							Computed:         true,
-							DiffSuppressFunc: suppressSensitiveFieldDiff,
-							Description:      "Absolute filesystem path to the client certificate on the Bytebase server. Mutually exclusive with ssl_cert.",
-						},
-						"ssl_key_path": {
-							Type:             schema.TypeString,
-							Optional:         true,
-							Computed:         true,
-							DiffSuppressFunc: suppressSensitiveFieldDiff,
-							Description:      "Absolute filesystem path to the client private key on the Bytebase server. Mutually exclusive with ssl_key.",
-						},
-						"ssl_ca_set": {
-							Type:        schema.TypeBool,
-							Computed:    true,
-							Description: "Whether an inline CA certificate has been configured.",
-						},
-						"ssl_cert_set": {
-							Type:        schema.TypeBool,
-							Computed:    true,
-							Description: "Whether an inline client certificate has been configured.",
-						},
-						"ssl_key_set": {
-							Type:        schema.TypeBool,
-							Computed:    true,
-							Description: "Whether an inline client private key has been configured.",
-						},
-						"ssl_ca_path_set": {
-							Type:        schema.TypeBool,
-							Computed:    true,
-							Description: "Whether a CA certificate path has been configured.",
-						},
-						"ssl_cert_path_set": {
-							Type:        schema.TypeBool,
-							Computed:    true,
-							Description: "Whether a client certificate path has been configured.",
-						},
-						"ssl_key_path_set": {
-							Type:        schema.TypeBool,
-							Computed:    true,
-							Description: "Whether a client private key path has been configured.",
-						},
-						"host": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "",
-							Description: "Host or socket for your instance, or the account name if the instance type is Snowflake. Not required for some engines like DYNAMODB.",
-						},
-						"port": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "",
-							Description: "The port for your instance. Not required for some engines like SPANNER, BIGQUERY.",
-						},
-						"database": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "",
-							Description: "The database for the instance, you can set this if the engine type is POSTGRES.",
-						},
-						// SSL/Security
-						"verify_tls_certificate": {
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-							Description: "Enable TLS certificate verification for SSL connections.",
-						},
-						// MongoDB-specific (only available for MONGODB engine)
-						"srv": {
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-							Description: "Use DNS SRV record for MongoDB connection. Only available for MONGODB engine.",
-						},
-						"authentication_database": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "",
-							Description: "The database to authenticate against for MongoDB. Only available for MONGODB engine.",
-						},
-						"replica_set": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "",
-							Description: "The replica set name for MongoDB. Only available for MONGODB engine.",
-						},
-						"direct_connection": {
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-							Description: "Use direct connection to MongoDB node. Only available for MONGODB engine.",
-						},
-						"additional_addresses": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							Description: "Additional addresses for MongoDB replica set. Only available for MONGODB engine.",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"host": {
-										Type:        schema.TypeString,
-										Required:    true,
-										Description: "The hostname of the additional address.",
-									},
-									"port": {
-										Type:        schema.TypeString,
-										Required:    true,
-										Description: "The port of the additional address.",
-									},
+								"engine_name": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "The name for secret engine.",
 								},
-							},
-						},
-						// Oracle-specific (only available for ORACLE engine)
-						"sid": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "",
-							Description: "Oracle System Identifier (SID). Only available for ORACLE engine.",
-						},
-						"service_name": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "",
-							Description: "Oracle service name. Only available for ORACLE engine.",
-						},
-						// SSH Tunneling (requires PASSWORD authentication_type and specific engines)
-						"ssh_host": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "",
-							Description: "SSH tunnel server hostname. Only available for MYSQL, TIDB, MARIADB, OCEANBASE, POSTGRES, REDIS with PASSWORD authentication.",
-						},
-						"ssh_port": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "",
-							Description: "SSH tunnel server port. Only available for MYSQL, TIDB, MARIADB, OCEANBASE, POSTGRES, REDIS with PASSWORD authentication.",
-						},
-						"ssh_user": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "",
-							Description: "SSH tunnel username. Only available for MYSQL, TIDB, MARIADB, OCEANBASE, POSTGRES, REDIS with PASSWORD authentication.",
-						},
-						"ssh_password": {
-							Type:             schema.TypeString,
-							Optional:         true,
-							Sensitive:        true,
-							Computed:         true,
-							DiffSuppressFunc: suppressSensitiveFieldDiff,
-							Description:      "SSH tunnel password. Only available for MYSQL, TIDB, MARIADB, OCEANBASE, POSTGRES, REDIS with PASSWORD authentication.",
-						},
-						"ssh_private_key": {
-							Type:             schema.TypeString,
-							Optional:         true,
-							Sensitive:        true,
-							Computed:         true,
-							DiffSuppressFunc: suppressSensitiveFieldDiff,
-							Description:      "SSH tunnel private key. Only available for MYSQL, TIDB, MARIADB, OCEANBASE, POSTGRES, REDIS with PASSWORD authentication.",
-						},
-						// Authentication
-						"authentication_type": {
-							Type:     schema.TypeString,
-							Optional: true,
-							ValidateFunc: validation.StringInSlice([]string{
-								v1pb.DataSource_PASSWORD.String(),
-								v1pb.DataSource_GOOGLE_CLOUD_SQL_IAM.String(),
-								v1pb.DataSource_AWS_RDS_IAM.String(),
-								v1pb.DataSource_AZURE_IAM.String(),
-							}, false),
-							Description: "Authentication type. Supported values depend on engine: " +
-								"COSMOSDB only supports AZURE_IAM; " +
-								"MSSQL supports PASSWORD, AZURE_IAM; " +
-								"ELASTICSEARCH supports PASSWORD, AWS_RDS_IAM; " +
-								"SPANNER, BIGQUERY only support GOOGLE_CLOUD_SQL_IAM; " +
-								"Most other engines support PASSWORD, GOOGLE_CLOUD_SQL_IAM, AWS_RDS_IAM. " +
-								"Default is PASSWORD.",
-						},
-						"cloud_sql_ip_type": {
-							Type:     schema.TypeString,
-							Optional: true,
-							ValidateFunc: validation.StringInSlice([]string{
-								v1pb.DataSource_PUBLIC.String(),
-								v1pb.DataSource_PRIVATE.String(),
-								v1pb.DataSource_PSC.String(),
-							}, false),
-							Description: "Cloud SQL IP type. Only available when authentication_type is GOOGLE_CLOUD_SQL_IAM. Defaults to PUBLIC.",
-						},
-						"authentication_private_key": {
-							Type:             schema.TypeString,
-							Optional:         true,
-							Sensitive:        true,
-							Computed:         true,
-							DiffSuppressFunc: suppressSensitiveFieldDiff,
-							Description:      "PKCS#8 private key for authentication.",
-						},
-						"authentication_private_key_passphrase": {
-							Type:             schema.TypeString,
-							Optional:         true,
-							Sensitive:        true,
-							Computed:         true,
-							DiffSuppressFunc: suppressSensitiveFieldDiff,
-							Description:      "Passphrase for encrypted private key.",
-						},
-						// Redis-specific (only available for REDIS engine)
-						"redis_type": {
-							Type:     schema.TypeString,
-							Optional: true,
-							ValidateFunc: validation.StringInSlice([]string{
-								v1pb.DataSource_STANDALONE.String(),
-								v1pb.DataSource_SENTINEL.String(),
-								v1pb.DataSource_CLUSTER.String(),
-							}, false),
-							Description: "Redis deployment type: STANDALONE, SENTINEL, CLUSTER. Only available for REDIS engine.",
-						},
-						"master_name": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "",
-							Description: "Redis Sentinel master name. Only available for REDIS engine.",
-						},
-						"master_username": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "",
-							Description: "Redis Sentinel master username. Only available for REDIS engine.",
-						},
-						"master_password": {
-							Type:             schema.TypeString,
-							Optional:         true,
-							Sensitive:        true,
-							Computed:         true,
-							DiffSuppressFunc: suppressSensitiveFieldDiff,
-							Description:      "Redis Sentinel master password. Only available for REDIS engine.",
-						},
-						// Cloud-specific
-						"region": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "",
-							Description: "AWS region (e.g., us-east-1). Only available when authentication_type is AWS_RDS_IAM.",
-						},
-						"warehouse_id": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "",
-							Description: "Databricks warehouse ID. Only available for DATABRICKS engine.",
-						},
-						"cluster": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "",
-							Description: "CockroachDB cluster name. Only available for COCKROACHDB engine.",
-						},
-						// IAM Credentials (each only valid for its respective authentication_type)
-						"azure_credential": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							MaxItems:    1,
-							Description: "Azure IAM credential. Only valid when authentication_type is AZURE_IAM.",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"tenant_id": {
-										Type:        schema.TypeString,
-										Required:    true,
-										Description: "Azure tenant ID.",
-									},
-									"client_id": {
-										Type:        schema.TypeString,
-										Required:    true,
-										Description: "Azure client ID.",
-									},
-									"client_secret": {
-										Type:        schema.TypeString,
-										Required:    true,
-										Sensitive:   true,
-										Description: "Azure client secret.",
-									},
+								"secret_name": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "The secret name in the engine to store the password.",
+								},
+								"password_key_name": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "The key name for the password.",
 								},
 							},
 						},
-						"aws_credential": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							MaxItems:    1,
-							Description: "AWS IAM credential. Only valid when authentication_type is AWS_RDS_IAM.",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"access_key_id": {
-										Type:        schema.TypeString,
-										Required:    true,
-										Description: "AWS access key ID.",
-									},
-									"secret_access_key": {
-										Type:        schema.TypeString,
-										Required:    true,
-										Sensitive:   true,
-										Description: "AWS secret access key.",
-									},
-									"session_token": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Sensitive:   true,
-										Description: "AWS session token.",
-									},
-									"role_arn": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "ARN of IAM role to assume for cross-account access.",
-									},
-									"external_id": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "External ID for additional security when assuming role.",
-									},
+					},
+					"aws_secrets_manager": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						MinItems:    0,
+						Description: "The AWS Secrets Manager to get the database password. Reference doc https://docs.aws.amazon.com/secretsmanager/latest/userguide/intro.html",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"secret_name": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "The secret name to store the password.",
+								},
+								"password_key_name": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "The key name for the password.",
 								},
 							},
 						},
-						"gcp_credential": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							MaxItems:    1,
-							Description: "GCP IAM credential (service account JSON). Only valid when authentication_type is GOOGLE_CLOUD_SQL_IAM.",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"content": {
-										Type:        schema.TypeString,
-										Required:    true,
-										Sensitive:   true,
-										Description: "GCP service account JSON content.",
-									},
+					},
+					"gcp_secret_manager": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						MinItems:    0,
+						Description: "The GCP Secret Manager to get the database password. Reference doc https://cloud.google.com/secret-manager/docs",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"secret_name": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "The secret name should be like \"projects/{project-id}/secrets/{secret-id}\".",
 								},
 							},
 						},
-						// SASL Config (only available for HIVE engine)
-						"sasl_config": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							MaxItems:    1,
-							Description: "SASL authentication configuration. Only available for HIVE engine.",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"kerberos": {
-										Type:        schema.TypeList,
-										Optional:    true,
-										MaxItems:    1,
-										Description: "Kerberos configuration.",
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"primary": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "The primary component of the Kerberos principal.",
-												},
-												"instance": {
-													Type:        schema.TypeString,
-													Optional:    true,
-													Description: "The instance component of the Kerberos principal.",
-												},
-												"realm": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "The Kerberos realm.",
-												},
-												"keytab": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Sensitive:   true,
-													Description: "The keytab file contents for authentication (base64 encoded).",
-												},
-												"kdc_host": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "The hostname of the Key Distribution Center (KDC).",
-												},
-												"kdc_port": {
-													Type:        schema.TypeString,
-													Optional:    true,
-													Description: "The port of the Key Distribution Center (KDC).",
-												},
-												"kdc_transport_protocol": {
-													Type:        schema.TypeString,
-													Optional:    true,
-													Description: "The transport protocol for KDC communication (tcp or udp).",
-												},
-											},
-										},
-									},
+					},
+				},
+			},
+		},
+		"use_ssl": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Enable SSL connection. Required to use SSL certificates.",
+		},
+		"ssl_ca": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Sensitive:        true,
+			Computed:         true,
+			DiffSuppressFunc: suppressSensitiveFieldDiff,
+			Description:      "The inline PEM CA certificate. Mutually exclusive with ssl_ca_path.",
+		},
+		"ssl_cert": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Sensitive:        true,
+			Computed:         true,
+			DiffSuppressFunc: suppressSensitiveFieldDiff,
+			Description:      "The inline PEM client certificate. Mutually exclusive with ssl_cert_path.",
+		},
+		"ssl_key": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Sensitive:        true,
+			Computed:         true,
+			DiffSuppressFunc: suppressSensitiveFieldDiff,
+			Description:      "The inline PEM client private key. Mutually exclusive with ssl_key_path.",
+		},
+		"ssl_ca_path": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			DiffSuppressFunc: suppressSensitiveFieldDiff,
+			Description:      "Absolute filesystem path to the CA certificate on the Bytebase server. Mutually exclusive with ssl_ca.",
+		},
+		"ssl_cert_path": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			DiffSuppressFunc: suppressSensitiveFieldDiff,
+			Description:      "Absolute filesystem path to the client certificate on the Bytebase server. Mutually exclusive with ssl_cert.",
+		},
+		"ssl_key_path": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			DiffSuppressFunc: suppressSensitiveFieldDiff,
+			Description:      "Absolute filesystem path to the client private key on the Bytebase server. Mutually exclusive with ssl_key.",
+		},
+		"ssl_ca_set": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether an inline CA certificate has been configured.",
+		},
+		"ssl_cert_set": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether an inline client certificate has been configured.",
+		},
+		"ssl_key_set": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether an inline client private key has been configured.",
+		},
+		"ssl_ca_path_set": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether a CA certificate path has been configured.",
+		},
+		"ssl_cert_path_set": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether a client certificate path has been configured.",
+		},
+		"ssl_key_path_set": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether a client private key path has been configured.",
+		},
+		"host": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "Host or socket for your instance, or the account name if the instance type is Snowflake. Not required for some engines like DYNAMODB.",
+		},
+		"port": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "The port for your instance. Not required for some engines like SPANNER, BIGQUERY.",
+		},
+		"database": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "The database for the instance, you can set this if the engine type is POSTGRES.",
+		},
+		// SSL/Security
+		"verify_tls_certificate": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Enable TLS certificate verification for SSL connections.",
+		},
+		// MongoDB-specific (only available for MONGODB engine)
+		"srv": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Use DNS SRV record for MongoDB connection. Only available for MONGODB engine.",
+		},
+		"authentication_database": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "The database to authenticate against for MongoDB. Only available for MONGODB engine.",
+		},
+		"replica_set": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "The replica set name for MongoDB. Only available for MONGODB engine.",
+		},
+		"direct_connection": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Use direct connection to MongoDB node. Only available for MONGODB engine.",
+		},
+		"additional_addresses": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Additional addresses for MongoDB replica set. Only available for MONGODB engine.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"host": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The hostname of the additional address.",
+					},
+					"port": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The port of the additional address.",
+					},
+				},
+			},
+		},
+		// Oracle-specific (only available for ORACLE engine)
+		"sid": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "Oracle System Identifier (SID). Only available for ORACLE engine.",
+		},
+		"service_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "Oracle service name. Only available for ORACLE engine.",
+		},
+		// SSH Tunneling (requires PASSWORD authentication_type and specific engines)
+		"ssh_host": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "SSH tunnel server hostname. Only available for MYSQL, TIDB, MARIADB, OCEANBASE, POSTGRES, REDIS with PASSWORD authentication.",
+		},
+		"ssh_port": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "SSH tunnel server port. Only available for MYSQL, TIDB, MARIADB, OCEANBASE, POSTGRES, REDIS with PASSWORD authentication.",
+		},
+		"ssh_user": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "SSH tunnel username. Only available for MYSQL, TIDB, MARIADB, OCEANBASE, POSTGRES, REDIS with PASSWORD authentication.",
+		},
+		"ssh_password": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Sensitive:        true,
+			Computed:         true,
+			DiffSuppressFunc: suppressSensitiveFieldDiff,
+			Description:      "SSH tunnel password. Only available for MYSQL, TIDB, MARIADB, OCEANBASE, POSTGRES, REDIS with PASSWORD authentication.",
+		},
+		"ssh_private_key": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Sensitive:        true,
+			Computed:         true,
+			DiffSuppressFunc: suppressSensitiveFieldDiff,
+			Description:      "SSH tunnel private key. Only available for MYSQL, TIDB, MARIADB, OCEANBASE, POSTGRES, REDIS with PASSWORD authentication.",
+		},
+		// Authentication
+		"authentication_type": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				v1pb.DataSource_PASSWORD.String(),
+				v1pb.DataSource_GOOGLE_CLOUD_SQL_IAM.String(),
+				v1pb.DataSource_AWS_RDS_IAM.String(),
+				v1pb.DataSource_AZURE_IAM.String(),
+			}, false),
+			Description: "Authentication type. Supported values depend on engine: " +
+				"COSMOSDB only supports AZURE_IAM; " +
+				"MSSQL supports PASSWORD, AZURE_IAM; " +
+				"ELASTICSEARCH supports PASSWORD, AWS_RDS_IAM; " +
+				"SPANNER, BIGQUERY only support GOOGLE_CLOUD_SQL_IAM; " +
+				"Most other engines support PASSWORD, GOOGLE_CLOUD_SQL_IAM, AWS_RDS_IAM. " +
+				"Default is PASSWORD.",
+		},
+		"cloud_sql_ip_type": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				v1pb.DataSource_PUBLIC.String(),
+				v1pb.DataSource_PRIVATE.String(),
+				v1pb.DataSource_PSC.String(),
+			}, false),
+			Description: "Cloud SQL IP type. Only available when authentication_type is GOOGLE_CLOUD_SQL_IAM. Defaults to PUBLIC.",
+		},
+		"authentication_private_key": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Sensitive:        true,
+			Computed:         true,
+			DiffSuppressFunc: suppressSensitiveFieldDiff,
+			Description:      "PKCS#8 private key for authentication.",
+		},
+		"authentication_private_key_passphrase": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Sensitive:        true,
+			Computed:         true,
+			DiffSuppressFunc: suppressSensitiveFieldDiff,
+			Description:      "Passphrase for encrypted private key.",
+		},
+		// Redis-specific (only available for REDIS engine)
+		"redis_type": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				v1pb.DataSource_STANDALONE.String(),
+				v1pb.DataSource_SENTINEL.String(),
+				v1pb.DataSource_CLUSTER.String(),
+			}, false),
+			Description: "Redis deployment type: STANDALONE, SENTINEL, CLUSTER. Only available for REDIS engine.",
+		},
+		"master_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "Redis Sentinel master name. Only available for REDIS engine.",
+		},
+		"master_username": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "Redis Sentinel master username. Only available for REDIS engine.",
+		},
+		"master_password": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Sensitive:        true,
+			Computed:         true,
+			DiffSuppressFunc: suppressSensitiveFieldDiff,
+			Description:      "Redis Sentinel master password. Only available for REDIS engine.",
+		},
+		// Cloud-specific
+		"region": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "AWS region (e.g., us-east-1). Only available when authentication_type is AWS_RDS_IAM.",
+		},
+		"warehouse_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "Databricks warehouse ID. Only available for DATABRICKS engine.",
+		},
+		"cluster": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "CockroachDB cluster name. Only available for COCKROACHDB engine.",
+		},
+		// IAM Credentials (each only valid for its respective authentication_type)
+		"azure_credential": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Azure IAM credential. Only valid when authentication_type is AZURE_IAM.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"tenant_id": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Azure tenant ID.",
+					},
+					"client_id": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Azure client ID.",
+					},
+					"client_secret": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Sensitive:   true,
+						Description: "Azure client secret. Required unless the credential_secret_wo is set in the data_source_credentials.",
+					},
+				},
+			},
+		},
+		"aws_credential": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "AWS IAM credential. Only valid when authentication_type is AWS_RDS_IAM.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"access_key_id": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "AWS access key ID.",
+					},
+					"secret_access_key": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Sensitive:   true,
+						Description: "AWS secret access key. Required unless the credential_secret_wo is set in the data_source_credentials.",
+					},
+					"session_token": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Sensitive:   true,
+						Description: "AWS session token.",
+					},
+					"role_arn": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "ARN of IAM role to assume for cross-account access.",
+					},
+					"external_id": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "External ID for additional security when assuming role.",
+					},
+				},
+			},
+		},
+		"gcp_credential": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "GCP IAM credential (service account JSON). Only valid when authentication_type is GOOGLE_CLOUD_SQL_IAM.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"content": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Sensitive:   true,
+						Description: "GCP service account JSON content. Required unless the credential_secret_wo is set in the data_source_credentials.",
+					},
+				},
+			},
+		},
+		// SASL Config (only available for HIVE engine)
+		"sasl_config": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "SASL authentication configuration. Only available for HIVE engine.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"kerberos": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Description: "Kerberos configuration.",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"primary": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "The primary component of the Kerberos principal.",
+								},
+								"instance": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Description: "The instance component of the Kerberos principal.",
+								},
+								"realm": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "The Kerberos realm.",
+								},
+								"keytab": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Sensitive:   true,
+									Description: "The keytab file contents for authentication (base64 encoded).",
+								},
+								"kdc_host": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "The hostname of the Key Distribution Center (KDC).",
+								},
+								"kdc_port": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Description: "The port of the Key Distribution Center (KDC).",
+								},
+								"kdc_transport_protocol": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Description: "The transport protocol for KDC communication (tcp or udp).",
 								},
 							},
 						},
-						// Extra connection parameters
-						"extra_connection_parameters": {
-							Type:        schema.TypeMap,
-							Optional:    true,
-							Description: "Extra connection parameters as key-value pairs. Only available for MYSQL, MARIADB, OCEANBASE, POSTGRES, ORACLE, MSSQL, MONGODB.",
-							Elem:        &schema.Schema{Type: schema.TypeString},
-						},
 					},
 				},
-				Set: dataSourceHash,
 			},
-			"list_all_databases": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "List all databases in this instance. If false, will only list 500 databases.",
-			},
-			"databases": getDatabasesSchema(true),
+		},
+		// Extra connection parameters
+		"extra_connection_parameters": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Description: "Extra connection parameters as key-value pairs. Only available for MYSQL, MARIADB, OCEANBASE, POSTGRES, ORACLE, MSSQL, MONGODB.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
 		},
 	}
 }
 
+// dataSourceCredentialKeys are the write-only credential attributes in the data_source_credentials.
+var dataSourceCredentialKeys = []string{
+	"password_wo",
+	"ssl_key_wo",
+	"ssh_private_key_wo",
+	"authentication_private_key_wo",
+	"credential_secret_wo",
+}
+
+func getDataSourceCredentialsSchema() *schema.Schema {
+	credentialSchema := getDataSourceCredentialSchema()
+	credentialSchema["id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringIsNotEmpty,
+		Description:  "The data source id in the data_sources.",
+	}
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "The write-only credentials for the data sources, matched by the data source id. The credentials are never saved in the state, change the paired *_version to rotate them.",
+		Elem: &schema.Resource{
+			Schema: credentialSchema,
+		},
+	}
+}
+
+// getDataSourceCredentialSchema returns the schema for the write-only credentials and their versions.
+func getDataSourceCredentialSchema() map[string]*schema.Schema {
+	credentialSchema := map[string]*schema.Schema{}
+	descriptions := map[string]string{
+		"password_wo":                   "The write-only connection password. Conflicts with the password in the data source.",
+		"ssl_key_wo":                    "The write-only inline PEM client private key. Conflicts with the ssl_key in the data source.",
+		"ssh_private_key_wo":            "The write-only SSH tunnel private key. Conflicts with the ssh_private_key in the data source.",
+		"authentication_private_key_wo": "The write-only PKCS#8 private key for authentication. Conflicts with the authentication_private_key in the data source.",
+		"credential_secret_wo":          "The write-only cloud credential secret, used as the azure_credential client_secret, the aws_credential secret_access_key or the gcp_credential content in the data source.",
+	}
+	for _, key := range dataSourceCredentialKeys {
+		credentialSchema[key] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			WriteOnly:   true,
+			Description: descriptions[key],
+		}
+		credentialSchema[fmt.Sprintf("%s_version", key)] = &schema.Schema{
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: fmt.Sprintf("The version for the %s. The write-only value is not saved in the state, change the version to update it.", key),
+		}
+	}
+	return credentialSchema
+}
+
 func getSyncDatabasesSchema(computed bool) *schema.Schema {
 	return &schema.Schema{
 		Type:        schema.TypeSet,
@@ -788,12 +883,18 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if err := applyDataSourceCredentials(d.GetRawConfig(), dataSourceList); err != nil {
+		return diag.FromErr(err)
+	}
 
 	instanceID := d.Get("resource_id").(string)
 	instanceName := fmt.Sprintf("%s%s", internal.InstanceNamePrefix, instanceID)
 	existedInstance, err := c.GetInstance(ctx, instanceName)
-	if err != nil {
-		tflog.Debug(ctx, fmt.Sprintf("get instance %s failed with error: %v", instanceName, err))
+	if err != nil && !internal.IsNotFoundError(err) {
+		return diag.Errorf("get instance %s failed with error: %v", instanceName, err)
+	}
+	if diags := checkAdoptExisting(d, "instance", instanceName, existedInstance != nil && err == nil, existedInstance.GetState()); diags.HasError() {
+		return diags
 	}
 
 	instance := &v1pb.Instance{
@@ -805,8 +906,8 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 		State:         v1pb.State_ACTIVE,
 		Engine:        v1pb.Engine(v1pb.Engine_value[d.Get("engine").(string)]),
 		SyncDatabases: getSyncDatabases(d),
-		Labels:        convertToStringMap(d.Get("labels").(map[string]interface{})),
 	}
+	instance.Labels, _ = getLabelsPatch(c.GetLabelConfig(), d, nil)
 	environment := d.Get("environment").(string)
 	if environment != "" {
 		instance.Environment = &environment
@@ -870,10 +971,12 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 		if config := rawConfig.GetAttr("sync_databases"); !config.IsNull() {
 			updateMasks = append(updateMasks, "sync_databases")
 		}
-		if config := rawConfig.GetAttr("labels"); !config.IsNull() && !maps.Equal(instance.Labels, existedInstance.Labels) {
+		if labels, ok := getLabelsPatch(c.GetLabelConfig(), d, existedInstance.Labels); ok {
+			instance.Labels = labels
 			updateMasks = append(updateMasks, "labels")
 		}
 		if len(dataSourceList) > 0 {
+			instance.DataSources = appendUnmanagedDataSources(dataSourceList, existedInstance, getDataSourceIDs(d.Get("data_sources")))
 			updateMasks = append(updateMasks, "data_sources")
 		}
 
@@ -897,14 +1000,43 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 		"instance": instanceName,
 	})
 
+	waitForSync := d.Get("wait_for_sync").(bool)
+	var lastSyncTime time.Time
+	if waitForSync {
+		// Record the sync time before the sync, so the wait completes once the instance is synced again.
+		createdInstance, err := c.GetInstance(ctx, instanceName)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		if v := createdInstance.LastSyncTime; v != nil {
+			lastSyncTime = v.AsTime()
+		}
+	}
 	if err := c.SyncInstanceSchema(ctx, instanceName); err != nil {
+		severity := diag.Warning
+		if waitForSync {
+			severity = diag.Error
+		}
 		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Warning,
+			Severity: severity,
 			Summary:  "Instance schema sync failed",
 			Detail:   fmt.Sprintf("Failed to sync schema for instance %s with error: %v. You can try to trigger the sync manually via Bytebase UI.", instanceName, err.Error()),
 		})
 	}
 	d.SetId(instanceName)
+	if diags.HasError() {
+		return diags
+	}
+
+	if waitForSync {
+		if err := waitForInstanceSync(ctx, c, instanceName, lastSyncTime, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Instance schema sync not finished",
+				Detail:   fmt.Sprintf("Failed to wait for the schema sync for instance %s with error: %v", instanceName, err.Error()),
+			})
+		}
+	}
 
 	tflog.Debug(ctx, "[upsert instance] sync schema finished. now reading instance", map[string]interface{}{
 		"instance": instanceName,
@@ -922,6 +1054,181 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 	return diags
 }
 
+// validateInstanceConnection tests the data source connections in the plan without changing the instance.
+// The new instance is validated with the validate only create request, the existing instance is validated with the
+// validate only add or update data source request for the changed data sources.
+// The error points to the data_sources attribute.
+func validateInstanceConnection(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if !diff.Get("validate_connection").(bool) {
+		return nil
+	}
+	if diff.Id() != "" && !diff.HasChange("data_sources") && !diff.HasChange("engine") {
+		return nil
+	}
+	for _, key := range []string{"resource_id", "engine"} {
+		if !diff.NewValueKnown(key) {
+			return nil
+		}
+	}
+	if config := diff.GetRawConfig().GetAttr("data_sources"); config.IsNull() || !config.IsWhollyKnown() {
+		return nil
+	}
+
+	dataSourceList, err := convertDataSourceCreateList(diff, true /* validate */)
+	if err != nil {
+		return err
+	}
+	if err := applyDataSourceCredentials(diff.GetRawConfig(), dataSourceList); err != nil {
+		return err
+	}
+
+	c := m.(api.Client)
+	if diff.Id() != "" && !diff.HasChange("engine") {
+		err = validateExistingInstanceConnection(ctx, c, diff, dataSourceList)
+	} else {
+		err = validateNewInstanceConnection(ctx, c, diff, dataSourceList)
+	}
+	if err != nil {
+		return cty.GetAttrPath("data_sources").NewError(err)
+	}
+	tflog.Debug(ctx, "[validate instance] data source connections validated", map[string]interface{}{
+		"instance":     diff.Get("resource_id").(string),
+		"data_sources": len(dataSourceList),
+	})
+	return nil
+}
+
+// validateNewInstanceConnection validates the admin data source first, then each read-only data source with the admin data source.
+func validateNewInstanceConnection(ctx context.Context, c api.Client, diff *schema.ResourceDiff, dataSourceList []*v1pb.DataSource) error {
+	var adminDataSource *v1pb.DataSource
+	readOnlyDataSources := []*v1pb.DataSource{}
+	for _, dataSource := range dataSourceList {
+		if dataSource.Type == v1pb.DataSourceType_ADMIN {
+			adminDataSource = dataSource
+		} else {
+			readOnlyDataSources = append(readOnlyDataSources, dataSource)
+		}
+	}
+
+	instanceID := diff.Get("resource_id").(string)
+	instance := &v1pb.Instance{
+		Name:   fmt.Sprintf("%s%s", internal.InstanceNamePrefix, instanceID),
+		Title:  diff.Get("title").(string),
+		Engine: v1pb.Engine(v1pb.Engine_value[diff.Get("engine").(string)]),
+		State:  v1pb.State_ACTIVE,
+	}
+	if environment := diff.Get("environment").(string); environment != "" {
+		instance.Environment = &environment
+	}
+
+	instance.DataSources = []*v1pb.DataSource{adminDataSource}
+	if err := c.ValidateInstance(ctx, instanceID, instance); err != nil {
+		return errors.Errorf("failed to connect the data source %q (%s) in instance %s: %v", adminDataSource.Id, adminDataSource.Type.String(), instanceID, err)
+	}
+	for _, dataSource := range readOnlyDataSources {
+		instance.DataSources = []*v1pb.DataSource{adminDataSource, dataSource}
+		if err := c.ValidateInstance(ctx, instanceID, instance); err != nil {
+			return errors.Errorf("failed to connect the data source %q (%s) in instance %s: %v", dataSource.Id, dataSource.Type.String(), instanceID, err)
+		}
+	}
+	return nil
+}
+
+// validateExistingInstanceConnection validates the changed data sources in the existing instance.
+// The data source in the instance is validated as updated, otherwise as added.
+func validateExistingInstanceConnection(ctx context.Context, c api.Client, diff *schema.ResourceDiff, dataSourceList []*v1pb.DataSource) error {
+	instanceName := diff.Id()
+	instance, err := c.GetInstance(ctx, instanceName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get instance %s", instanceName)
+	}
+	existedDataSources := map[string]bool{}
+	for _, dataSource := range instance.DataSources {
+		existedDataSources[dataSource.Id] = true
+	}
+
+	oldDataSources, newDataSources := diff.GetChange("data_sources")
+	changedDataSources := getDataSourceIDs(newDataSources.(*schema.Set).Difference(oldDataSources.(*schema.Set)))
+	for _, dataSource := range dataSourceList {
+		if !changedDataSources[dataSource.Id] {
+			continue
+		}
+		var updateMasks []string
+		if existedDataSources[dataSource.Id] {
+			updateMasks = getDataSourceValidateUpdateMasks(dataSource)
+		}
+		if err := c.ValidateDataSource(ctx, instanceName, dataSource, updateMasks); err != nil {
+			return errors.Errorf("failed to connect the data source %q (%s) in instance %s: %v", dataSource.Id, dataSource.Type.String(), instanceName, err)
+		}
+	}
+	return nil
+}
+
+// getDataSourceValidateUpdateMasks returns the update masks to validate the data source in the plan.
+// The sensitive and message fields are only included if set, so the credentials in the instance are used if not configured.
+func getDataSourceValidateUpdateMasks(dataSource *v1pb.DataSource) []string {
+	message := dataSource.ProtoReflect()
+	fields := message.Descriptor().Fields()
+	updateMasks := []string{}
+	for key, fieldSchema := range getDataSourceSchema() {
+		if key == "id" || key == "type" {
+			continue
+		}
+		field := fields.ByName(protoreflect.Name(key))
+		if field == nil {
+			continue
+		}
+		if (fieldSchema.Sensitive || field.Message() != nil) && !message.Has(field) {
+			continue
+		}
+		updateMasks = append(updateMasks, key)
+	}
+	slices.Sort(updateMasks)
+	return updateMasks
+}
+
+// waitForInstanceSync polls until the instance last sync time is after the lastSyncTime, then checks the sync error in the databases synced since then.
+// An instance without databases completes the wait once it's synced.
+func waitForInstanceSync(ctx context.Context, client api.Client, instanceName string, lastSyncTime time.Time, timeout time.Duration) error {
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		instance, err := client.GetInstance(ctx, instanceName)
+		if err != nil {
+			return retry.RetryableError(err)
+		}
+		if v := instance.LastSyncTime; v == nil || !v.AsTime().After(lastSyncTime) {
+			return retry.RetryableError(errors.Errorf("instance %s is not synced yet", instanceName))
+		}
+		databases, err := client.ListDatabase(ctx, instanceName, &api.DatabaseFilter{}, false)
+		if err != nil {
+			return retry.RetryableError(err)
+		}
+		if err := getDatabaseSyncError(databases, lastSyncTime); err != nil {
+			return retry.NonRetryableError(err)
+		}
+		tflog.Debug(ctx, "[upsert instance] instance synced", map[string]interface{}{
+			"instance":       instanceName,
+			"last_sync_time": instance.LastSyncTime.AsTime().UTC().Format(time.RFC3339),
+			"databases":      len(databases),
+		})
+		return nil
+	})
+}
+
+// getDatabaseSyncError returns the sync error of the database synced after the lastSyncTime.
+// The old sync errors on the databases not synced since the lastSyncTime are ignored.
+func getDatabaseSyncError(databases []*v1pb.Database, lastSyncTime time.Time) error {
+	for _, database := range databases {
+		if database.SyncError == "" {
+			continue
+		}
+		if v := database.SuccessfulSyncTime; v == nil || !v.AsTime().After(lastSyncTime) {
+			continue
+		}
+		return errors.Errorf("database %s sync failed: %s", database.Name, database.SyncError)
+	}
+	return nil
+}
+
 func resourceInstanceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(api.Client)
 	instanceName := d.Id()
@@ -938,7 +1245,22 @@ func resourceInstanceRead(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.FromErr(err)
 	}
 
+	if managedDataSources := getDataSourceIDs(d.Get("data_sources")); len(managedDataSources) > 0 {
+		// Skip the READ_ONLY data sources managed by the bytebase_instance_data_source resource.
+		instance = proto.Clone(instance).(*v1pb.Instance)
+		instance.DataSources = slices.DeleteFunc(instance.DataSources, func(dataSource *v1pb.DataSource) bool {
+			return dataSource.Type == v1pb.DataSourceType_READ_ONLY && !managedDataSources[dataSource.Id]
+		})
+	}
+
+	managed := d.Get("labels").(map[string]interface{})
 	resp := setInstanceMessage(ctx, c, d, instance)
+	if resp.HasError() {
+		return resp
+	}
+	if err := d.Set("labels", flattenLabels(c.GetLabelConfig(), instance.Labels, managed)); err != nil {
+		return diag.Errorf("cannot set labels for instance: %s", err.Error())
+	}
 	tflog.Debug(ctx, "[read instance] read instance finished", map[string]interface{}{
 		"instance": instance.Name,
 	})
@@ -995,6 +1317,14 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if err := applyDataSourceCredentials(d.GetRawConfig(), dataSourceList); err != nil {
+		return diag.FromErr(err)
+	}
+
+	oldDataSources, newDataSources := d.GetChange("data_sources")
+	managedDataSources := getDataSourceIDs(oldDataSources)
+	maps.Copy(managedDataSources, getDataSourceIDs(newDataSources))
+	dataSourceList = appendUnmanagedDataSources(dataSourceList, existedInstance, managedDataSources)
 
 	paths := []string{"data_sources"}
 	if d.HasChange("title") {
@@ -1018,7 +1348,8 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	if d.HasChange("sync_databases") {
 		paths = append(paths, "sync_databases")
 	}
-	if d.HasChange("labels") {
+	labels, ok := getLabelsPatch(c.GetLabelConfig(), d, existedInstance.Labels)
+	if ok {
 		paths = append(paths, "labels")
 	}
 
@@ -1035,7 +1366,7 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 				Seconds: int64(d.Get("sync_interval").(int)),
 			},
 			SyncDatabases: getSyncDatabases(d),
-			Labels:        convertToStringMap(d.Get("labels").(map[string]interface{})),
+			Labels:        labels,
 		}, paths); err != nil {
 			return diag.FromErr(err)
 		}
@@ -1180,7 +1511,11 @@ func flattenDataSourceList(d *schema.ResourceData, dataSourceList []*v1pb.DataSo
 			oldDataSourceMap[ds.Id] = ds
 		}
 	}
+	return flattenDataSources(oldDataSourceMap, dataSourceList, engine), nil
+}
 
+// flattenDataSources converts the data sources to the state, the sensitive fields not returned by the API are propagated from the old data sources.
+func flattenDataSources(oldDataSourceMap map[string]*v1pb.DataSource, dataSourceList []*v1pb.DataSource, engine v1pb.Engine) []interface{} {
 	res := []interface{}{}
 	for _, dataSource := range dataSourceList {
 		raw := map[string]interface{}{}
@@ -1396,7 +1731,7 @@ func flattenDataSourceList(d *schema.ResourceData, dataSourceList []*v1pb.DataSo
 		}
 		res = append(res, raw)
 	}
-	return res, nil
+	return res
 }
 
 func dataSourceHash(rawDataSource interface{}) int {
@@ -1736,7 +2071,147 @@ func convertToV1DataSource(raw interface{}) (*v1pb.DataSource, error) {
 	return dataSource, nil
 }
 
-func convertDataSourceCreateList(d *schema.ResourceData, validate bool) ([]*v1pb.DataSource, error) {
+// getDataSourceIDs returns the data source ids in the data_sources set.
+func getDataSourceIDs(rawSet interface{}) map[string]bool {
+	ids := map[string]bool{}
+	set, ok := rawSet.(*schema.Set)
+	if !ok {
+		return ids
+	}
+	for _, raw := range set.List() {
+		if id, ok := raw.(map[string]interface{})["id"].(string); ok {
+			ids[id] = true
+		}
+	}
+	return ids
+}
+
+// appendUnmanagedDataSources appends the READ_ONLY data sources in the instance but not in the managed ids,
+// so the data sources managed by the bytebase_instance_data_source resource are kept on update.
+func appendUnmanagedDataSources(dataSourceList []*v1pb.DataSource, instance *v1pb.Instance, managed map[string]bool) []*v1pb.DataSource {
+	for _, dataSource := range instance.GetDataSources() {
+		if dataSource.Type == v1pb.DataSourceType_READ_ONLY && !managed[dataSource.Id] {
+			dataSourceList = append(dataSourceList, dataSource)
+		}
+	}
+	return dataSourceList
+}
+
+// applyDataSourceCredentials sets the write-only credentials in the data_source_credentials to the data sources.
+// The write-only values only exist in the raw config.
+func applyDataSourceCredentials(rawConfig cty.Value, dataSourceList []*v1pb.DataSource) error {
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+	credentials := rawConfig.GetAttr("data_source_credentials")
+	if !credentials.IsNull() && credentials.IsKnown() {
+		if err := setDataSourceCredentials(credentials, dataSourceList); err != nil {
+			return err
+		}
+	}
+
+	return validateCloudCredentialSecrets(dataSourceList)
+}
+
+// validateCloudCredentialSecrets checks the cloud credential secrets are set after the write-only credentials applied.
+func validateCloudCredentialSecrets(dataSourceList []*v1pb.DataSource) error {
+	for _, dataSource := range dataSourceList {
+		if v := dataSource.GetAzureCredential(); v != nil && v.ClientSecret == "" {
+			return errors.Errorf("client_secret or credential_secret_wo is required for the azure_credential in data source %q", dataSource.Id)
+		}
+		if v := dataSource.GetAwsCredential(); v != nil && v.SecretAccessKey == "" {
+			return errors.Errorf("secret_access_key or credential_secret_wo is required for the aws_credential in data source %q", dataSource.Id)
+		}
+		if v := dataSource.GetGcpCredential(); v != nil && v.Content == "" {
+			return errors.Errorf("content or credential_secret_wo is required for the gcp_credential in data source %q", dataSource.Id)
+		}
+	}
+	return nil
+}
+
+func setDataSourceCredentials(credentials cty.Value, dataSourceList []*v1pb.DataSource) error {
+	dataSourceMap := map[string]*v1pb.DataSource{}
+	for _, dataSource := range dataSourceList {
+		dataSourceMap[dataSource.Id] = dataSource
+	}
+	seen := map[string]bool{}
+	for it := credentials.ElementIterator(); it.Next(); {
+		_, credential := it.Element()
+		id := credential.GetAttr("id")
+		if id.IsNull() || !id.IsKnown() {
+			continue
+		}
+		dataSourceID := id.AsString()
+		if seen[dataSourceID] {
+			return errors.Errorf("duplicate data_source_credentials for data source %q", dataSourceID)
+		}
+		seen[dataSourceID] = true
+		dataSource, ok := dataSourceMap[dataSourceID]
+		if !ok {
+			return errors.Errorf("data source %q in data_source_credentials not found in data_sources", dataSourceID)
+		}
+
+		values := map[string]string{}
+		for _, key := range dataSourceCredentialKeys {
+			if v := credential.GetAttr(key); !v.IsNull() && v.IsKnown() {
+				values[key] = v.AsString()
+			}
+		}
+		if err := setDataSourceCredential(dataSource, values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setDataSourceCredential sets the write-only credential values keyed by the attribute name to the data source.
+func setDataSourceCredential(dataSource *v1pb.DataSource, values map[string]string) error {
+	dataSourceID := dataSource.Id
+	if v, ok := values["password_wo"]; ok {
+		if dataSource.Password != "" {
+			return errors.Errorf("cannot set both password and password_wo for data source %q", dataSourceID)
+		}
+		if dataSource.ExternalSecret != nil {
+			return errors.Errorf("cannot set both password_wo and external_secret for data source %q", dataSourceID)
+		}
+		dataSource.Password = v
+	}
+	if v, ok := values["ssl_key_wo"]; ok {
+		if dataSource.SslKey != "" {
+			return errors.Errorf("cannot set both ssl_key and ssl_key_wo for data source %q", dataSourceID)
+		}
+		dataSource.SslKey = v
+	}
+	if v, ok := values["ssh_private_key_wo"]; ok {
+		if dataSource.SshPrivateKey != "" {
+			return errors.Errorf("cannot set both ssh_private_key and ssh_private_key_wo for data source %q", dataSourceID)
+		}
+		dataSource.SshPrivateKey = v
+	}
+	if v, ok := values["authentication_private_key_wo"]; ok {
+		if dataSource.AuthenticationPrivateKey != "" {
+			return errors.Errorf("cannot set both authentication_private_key and authentication_private_key_wo for data source %q", dataSourceID)
+		}
+		dataSource.AuthenticationPrivateKey = v
+	}
+	if v, ok := values["credential_secret_wo"]; ok {
+		switch {
+		case dataSource.GetAzureCredential() != nil:
+			dataSource.GetAzureCredential().ClientSecret = v
+		case dataSource.GetAwsCredential() != nil:
+			dataSource.GetAwsCredential().SecretAccessKey = v
+		case dataSource.GetGcpCredential() != nil:
+			dataSource.GetGcpCredential().Content = v
+		default:
+			return errors.Errorf("credential_secret_wo requires the azure_credential, aws_credential or gcp_credential in data source %q", dataSourceID)
+		}
+	}
+	return nil
+}
+
+func convertDataSourceCreateList(d interface {
+	Get(string) interface{}
+}, validate bool) ([]*v1pb.DataSource, error) {
 	var dataSourceList []*v1pb.DataSource
 	dataSourceSet, ok := d.Get("data_sources").(*schema.Set)
 	if !ok {
@@ -1921,7 +2396,22 @@ func validateDataSourceFieldsForEngine(engine v1pb.Engine, ds *v1pb.DataSource)
 	return nil
 }
 
+// findExistingInstance returns the name and state of the existing instance by the resource id.
+func findExistingInstance(ctx context.Context, c api.Client, instanceID string) (string, v1pb.State, error) {
+	instance, err := c.GetInstance(ctx, fmt.Sprintf("%s%s", internal.InstanceNamePrefix, instanceID))
+	if err != nil {
+		return "", v1pb.State_STATE_UNSPECIFIED, err
+	}
+	return instance.Name, instance.State, nil
+}
+
 func resourceInstanceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if diags := checkDeletionProtection(d, "instance"); diags.HasError() {
+		return diags
+	}
 	c := m.(api.Client)
-	return internal.ResourceDelete(ctx, d, c.DeleteInstance)
+	purge := d.Get("purge_on_destroy").(bool)
+	return internal.ResourceDelete(ctx, d, func(ctx context.Context, name string) error {
+		return c.DeleteInstance(ctx, name, purge)
+	})
 }
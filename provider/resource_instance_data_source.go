@@ -0,0 +1,271 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func resourceInstanceDataSource() *schema.Resource {
+	dataSourceSchema := getDataSourceSchema()
+	delete(dataSourceSchema, "id")
+	dataSourceSchema["instance"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+		ValidateDiagFunc: internal.ResourceNameValidation(
+			fmt.Sprintf("^%s%s$", internal.InstanceNamePrefix, internal.ResourceIDPattern),
+		),
+		Description: "The instance full name in instances/{resource id} format.",
+	}
+	dataSourceSchema["data_source_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringIsNotEmpty,
+		Description:  "The unique data source id in the instance.",
+	}
+	dataSourceSchema["type"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+		Default:  v1pb.DataSourceType_READ_ONLY.String(),
+		ValidateFunc: validation.StringInSlice([]string{
+			v1pb.DataSourceType_READ_ONLY.String(),
+		}, false),
+		Description: "The data source type. Only READ_ONLY is supported, the ADMIN data source is managed in the bytebase_instance. Requires the instance license.",
+	}
+	maps.Copy(dataSourceSchema, getDataSourceCredentialSchema())
+
+	return &schema.Resource{
+		Description:   "The READ_ONLY data source for the instance. Use it to manage the read replicas separately from the bytebase_instance, the bytebase_instance keeps the READ_ONLY data sources not declared in its data_sources.",
+		CreateContext: resourceInstanceDataSourceCreate,
+		ReadContext:   resourceInstanceDataSourceRead,
+		UpdateContext: resourceInstanceDataSourceUpdate,
+		DeleteContext: resourceInstanceDataSourceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: dataSourceSchema,
+	}
+}
+
+// getInstanceDataSourceName returns the data source name in instances/{instance}/dataSources/{data source} format.
+func getInstanceDataSourceName(instanceName, dataSourceID string) string {
+	return fmt.Sprintf("%s/%s%s", instanceName, internal.DataSourceIDPrefix, dataSourceID)
+}
+
+// convertToInstanceDataSource converts the resource to the data source, with the write-only credentials in the raw config.
+func convertToInstanceDataSource(d *schema.ResourceData, withCredentials bool) (*v1pb.DataSource, error) {
+	raw := map[string]interface{}{}
+	for key := range getDataSourceSchema() {
+		raw[key] = d.Get(key)
+	}
+	raw["id"] = d.Get("data_source_id").(string)
+
+	dataSource, err := convertToV1DataSource(raw)
+	if err != nil {
+		return nil, err
+	}
+	if !withCredentials {
+		return dataSource, nil
+	}
+
+	values := map[string]string{}
+	for _, key := range dataSourceCredentialKeys {
+		if v := getWriteOnlyString(d, key); v != "" {
+			values[key] = v
+		}
+	}
+	if err := setDataSourceCredential(dataSource, values); err != nil {
+		return nil, err
+	}
+	if err := validateCloudCredentialSecrets([]*v1pb.DataSource{dataSource}); err != nil {
+		return nil, err
+	}
+	return dataSource, nil
+}
+
+func resourceInstanceDataSourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	instanceName := d.Get("instance").(string)
+
+	instance, err := c.GetInstance(ctx, instanceName)
+	if err != nil {
+		return diag.Errorf("failed to get instance %s with error: %v", instanceName, err.Error())
+	}
+	dataSource, err := convertToInstanceDataSource(d, true /* with credentials */)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := validateAuthenticationTypeForEngine(instance.Engine, dataSource.AuthenticationType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := validateDataSourceFieldsForEngine(instance.Engine, dataSource); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := c.AddDataSource(ctx, instanceName, dataSource); err != nil {
+		return diag.Errorf("failed to add data source %s to instance %s with error: %v", dataSource.Id, instanceName, err.Error())
+	}
+
+	d.SetId(getInstanceDataSourceName(instanceName, dataSource.Id))
+	return resourceInstanceDataSourceRead(ctx, d, m)
+}
+
+func resourceInstanceDataSourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	instanceID, dataSourceID, err := internal.GetInstanceDataSourceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	instanceName := fmt.Sprintf("%s%s", internal.InstanceNamePrefix, instanceID)
+
+	instance, err := c.GetInstance(ctx, instanceName)
+	if err != nil {
+		if internal.IsNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Resource %s not found, removing from state", instanceName))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	index := slices.IndexFunc(instance.DataSources, func(dataSource *v1pb.DataSource) bool {
+		return dataSource.Id == dataSourceID
+	})
+	if index < 0 {
+		tflog.Warn(ctx, fmt.Sprintf("data source %s not found in instance %s, remove it from the state", dataSourceID, instanceName))
+		d.SetId("")
+		return nil
+	}
+
+	// The sensitive fields are not returned by the API, use the state values.
+	oldDataSourceMap := map[string]*v1pb.DataSource{}
+	if d.Get("data_source_id").(string) != "" {
+		if oldDataSource, err := convertToInstanceDataSource(d, false /* with credentials */); err == nil {
+			oldDataSourceMap[dataSourceID] = oldDataSource
+		}
+	}
+	raw := flattenDataSources(oldDataSourceMap, instance.DataSources[index:index+1], instance.Engine)[0].(map[string]interface{})
+	// The PASSWORD is the default authentication type, keep it empty if not configured.
+	if raw["authentication_type"] == v1pb.DataSource_PASSWORD.String() && d.Get("authentication_type").(string) == "" {
+		raw["authentication_type"] = ""
+	}
+
+	if err := d.Set("instance", instanceName); err != nil {
+		return diag.Errorf("cannot set instance for data source: %s", err.Error())
+	}
+	if err := d.Set("data_source_id", dataSourceID); err != nil {
+		return diag.Errorf("cannot set data_source_id for data source: %s", err.Error())
+	}
+	for key := range getDataSourceSchema() {
+		if key == "id" {
+			continue
+		}
+		if err := d.Set(key, raw[key]); err != nil {
+			return diag.Errorf("cannot set %s for data source: %s", key, err.Error())
+		}
+	}
+	return nil
+}
+
+// dataSourceCredentialUpdateMasks maps the write-only credential to the data source update mask.
+var dataSourceCredentialUpdateMasks = map[string]string{
+	"password_wo":                   "password",
+	"ssl_key_wo":                    "ssl_key",
+	"ssh_private_key_wo":            "ssh_private_key",
+	"authentication_private_key_wo": "authentication_private_key",
+}
+
+// getInstanceDataSourceUpdateMasks returns the update masks for the changed data source fields.
+func getInstanceDataSourceUpdateMasks(d *schema.ResourceData, dataSource *v1pb.DataSource) []string {
+	updateMasks := []string{}
+	for key := range getDataSourceSchema() {
+		// The id and type are force new, the *_set fields are computed.
+		if key == "id" || key == "type" || strings.HasSuffix(key, "_set") {
+			continue
+		}
+		if d.HasChange(key) {
+			updateMasks = append(updateMasks, key)
+		}
+	}
+	for _, key := range dataSourceCredentialKeys {
+		if !d.HasChange(fmt.Sprintf("%s_version", key)) {
+			continue
+		}
+		if updateMask, ok := dataSourceCredentialUpdateMasks[key]; ok {
+			updateMasks = append(updateMasks, updateMask)
+			continue
+		}
+		switch {
+		case dataSource.GetAzureCredential() != nil:
+			updateMasks = append(updateMasks, "azure_credential")
+		case dataSource.GetAwsCredential() != nil:
+			updateMasks = append(updateMasks, "aws_credential")
+		case dataSource.GetGcpCredential() != nil:
+			updateMasks = append(updateMasks, "gcp_credential")
+		default:
+		}
+	}
+	slices.Sort(updateMasks)
+	return slices.Compact(updateMasks)
+}
+
+func resourceInstanceDataSourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	instanceName := d.Get("instance").(string)
+
+	instance, err := c.GetInstance(ctx, instanceName)
+	if err != nil {
+		return diag.Errorf("failed to get instance %s with error: %v", instanceName, err.Error())
+	}
+	dataSource, err := convertToInstanceDataSource(d, true /* with credentials */)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := validateAuthenticationTypeForEngine(instance.Engine, dataSource.AuthenticationType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := validateDataSourceFieldsForEngine(instance.Engine, dataSource); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if updateMasks := getInstanceDataSourceUpdateMasks(d, dataSource); len(updateMasks) > 0 {
+		if _, err := c.UpdateDataSource(ctx, instanceName, dataSource, updateMasks); err != nil {
+			return diag.Errorf("failed to update data source %s in instance %s with error: %v", dataSource.Id, instanceName, err.Error())
+		}
+	}
+
+	return resourceInstanceDataSourceRead(ctx, d, m)
+}
+
+func resourceInstanceDataSourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	instanceID, dataSourceID, err := internal.GetInstanceDataSourceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	instanceName := fmt.Sprintf("%s%s", internal.InstanceNamePrefix, instanceID)
+
+	if _, err := c.RemoveDataSource(ctx, instanceName, &v1pb.DataSource{
+		Id:   dataSourceID,
+		Type: v1pb.DataSourceType_READ_ONLY,
+	}); err != nil && !internal.IsNotFoundError(err) {
+		return diag.Errorf("failed to remove data source %s from instance %s with error: %v", dataSourceID, instanceName, err.Error())
+	}
+
+	d.SetId("")
+	return nil
+}
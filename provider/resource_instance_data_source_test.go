@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func TestAccInstanceDataSource(t *testing.T) {
+	instanceID := "data-source-instance"
+	resourceName := "bytebase_instance_data_source.replica"
+
+	base := testAccCheckInstanceResource("data_source_instance", instanceID, "data source instance", "POSTGRES", "environments/test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// add the read-only data source
+			{
+				Config: testAccCheckInstanceDataSourceResource(base, "127.0.0.2"),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("instances/%s/dataSources/read-only", instanceID)),
+					resource.TestCheckResourceAttr(resourceName, "type", "READ_ONLY"),
+					resource.TestCheckResourceAttr(resourceName, "host", "127.0.0.2"),
+					// the instance resource ignores the data source
+					resource.TestCheckResourceAttr("bytebase_instance.data_source_instance", "data_sources.#", "1"),
+				),
+			},
+			// update the host, the instance keeps the data source
+			{
+				Config: testAccCheckInstanceDataSourceResource(base, "127.0.0.3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "host", "127.0.0.3"),
+					resource.TestCheckResourceAttr("bytebase_instance.data_source_instance", "data_sources.#", "1"),
+					resource.TestCheckResourceAttr("data.bytebase_instance.data_source_instance", "data_sources.#", "2"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "password_wo_version"},
+			},
+		},
+	})
+}
+
+func testAccCheckInstanceDataSourceResource(base, host string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "bytebase_instance_data_source" "replica" {
+	instance            = bytebase_instance.data_source_instance.name
+	data_source_id      = "read-only"
+	username            = "reader"
+	host                = "%s"
+	port                = "5432"
+	password_wo         = "secret"
+	password_wo_version = 1
+}
+
+data "bytebase_instance" "data_source_instance" {
+	resource_id = bytebase_instance.data_source_instance.resource_id
+	depends_on  = [bytebase_instance_data_source.replica]
+}
+`, base, host)
+}
+
+func TestGetInstanceDataSourceID(t *testing.T) {
+	name := getInstanceDataSourceName("instances/i", "read-only")
+	if name != "instances/i/dataSources/read-only" {
+		t.Fatalf("getInstanceDataSourceName() = %q", name)
+	}
+	instanceID, dataSourceID, err := internal.GetInstanceDataSourceID(name)
+	if err != nil {
+		t.Fatalf("GetInstanceDataSourceID(%q) error = %v", name, err)
+	}
+	if instanceID != "i" || dataSourceID != "read-only" {
+		t.Errorf("GetInstanceDataSourceID(%q) = %q, %q, want %q, %q", name, instanceID, dataSourceID, "i", "read-only")
+	}
+	if _, _, err := internal.GetInstanceDataSourceID("instances/i/databases/db"); err == nil {
+		t.Errorf("GetInstanceDataSourceID() expect error for the database name")
+	}
+}
@@ -0,0 +1,722 @@
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+// instanceRoleAttributes are the PostgreSQL role attributes, each one can be revoked by the NO prefix.
+var instanceRoleAttributes = []string{
+	"SUPERUSER",
+	"CREATEDB",
+	"CREATEROLE",
+	"INHERIT",
+	"LOGIN",
+	"REPLICATION",
+	"BYPASSRLS",
+}
+
+// instanceRoleEngines are the instance engines supporting the instance role.
+var instanceRoleEngines = []v1pb.Engine{
+	v1pb.Engine_POSTGRES,
+	v1pb.Engine_MYSQL,
+}
+
+// scramIterations is the iteration count for the SCRAM-SHA-256 password, same as the PostgreSQL default.
+const scramIterations = 4096
+
+const (
+	// cachingSHA2Rounds is the SHA-256 crypt rounds for the MySQL caching_sha2_password, same as the MySQL default.
+	cachingSHA2Rounds = 5000
+	// cachingSHA2SaltLength is the salt length for the MySQL caching_sha2_password.
+	cachingSHA2SaltLength = 20
+	// defaultMySQLUserHost is the MySQL user host if not set.
+	defaultMySQLUserHost = "%"
+)
+
+func resourceInstanceRole() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The database role in the PostgreSQL instance, or the user in the MySQL instance. The role statements run through the Bytebase issue in the project, so the changes follow the project approval flow, and the create, update and delete wait for the issue rollout. The password is sent as the SCRAM-SHA-256 secret for PostgreSQL and the caching_sha2_password hash for MySQL, not in plain text. The attributes, connection_limit and valid_until are PostgreSQL only. The attributes and valid_until are not read back from the instance, so the changes outside Terraform are not detected.",
+		CreateContext: resourceInstanceRoleCreate,
+		ReadContext:   resourceInstanceRoleRead,
+		UpdateContext: resourceInstanceRoleUpdate,
+		DeleteContext: resourceInstanceRoleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceInstanceRoleImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"instance": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateDiagFunc: internal.ResourceNameValidation(
+					fmt.Sprintf("^%s%s$", internal.InstanceNamePrefix, internal.ResourceIDPattern),
+				),
+				Description: "The instance full name in instances/{resource id} format. Only the POSTGRES and MYSQL instances are supported.",
+			},
+			"role_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The role name, or the user name for the MySQL instance.",
+			},
+			"host": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The host of the MySQL user, default %. Only for the MySQL instance.",
+			},
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateDiagFunc: internal.ResourceNameValidation(
+					fmt.Sprintf("^%s%s$", internal.ProjectNamePrefix, internal.ResourceIDPattern),
+				),
+				Description: "The project full name in projects/{project} format to create the issues for the role changes. The database should belong to the project.",
+			},
+			"database": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The database in the instance to run the role statements. Default postgres for the PostgreSQL instance, required for the MySQL instance.",
+			},
+			"password_wo": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "The write-only role password. Leave it empty for the role without password.",
+			},
+			"password_wo_version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The version for the password_wo. The write-only value is not saved in the state, change the version to update it.",
+			},
+			"connection_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validation.IntAtLeast(-1),
+				Description:  "The connection count limit for the role, -1 means no limit. Only for the PostgreSQL instance.",
+			},
+			"valid_until": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The expiration for the role password, for example 2030-01-01 00:00:00+00. Leave it empty to never expire. It's not read back from the instance. Only for the PostgreSQL instance.",
+			},
+			"attributes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(instanceRoleAttributes, false),
+				},
+				Description: fmt.Sprintf("The role attributes, should be in %s. The attributes not in the set are revoked, including the INHERIT granted by PostgreSQL by default. It's not read back from the instance. Only for the PostgreSQL instance.", strings.Join(instanceRoleAttributes, ", ")),
+			},
+			"attribute": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The role attribute synced from the instance.",
+			},
+		},
+	}
+}
+
+// getInstanceRoleName returns the role full name in instances/{instance}/roles/{role} format.
+// The role is in {user}@{host} format for the MySQL user.
+func getInstanceRoleName(instanceName, roleName string) string {
+	return fmt.Sprintf("%s/roles/%s", instanceName, roleName)
+}
+
+// getMySQLUserRoleName returns the role in {user}@{host} format for the MySQL user.
+func getMySQLUserRoleName(user, host string) string {
+	return fmt.Sprintf("%s@%s", user, host)
+}
+
+// parseMySQLUserRoleName parses the role in {user}@{host} format into the MySQL user and host.
+func parseMySQLUserRoleName(roleName string) (string, string, error) {
+	index := strings.LastIndex(roleName, "@")
+	if index <= 0 || index == len(roleName)-1 {
+		return "", "", errors.Errorf("invalid MySQL user %s, expect {user}@{host}", roleName)
+	}
+	return roleName[:index], roleName[index+1:], nil
+}
+
+// getMySQLUserSyncName returns the MySQL user name synced from the instance.
+func getMySQLUserSyncName(user, host string) string {
+	return fmt.Sprintf("'%s'@'%s'", user, host)
+}
+
+// quoteMySQLUser returns the quoted MySQL user in 'user'@'host' format for the statement.
+func quoteMySQLUser(user, host string) string {
+	return fmt.Sprintf("%s@%s", quoteMySQLLiteral(user), quoteMySQLLiteral(host))
+}
+
+func quoteMySQLLiteral(literal string) string {
+	return fmt.Sprintf("'%s'", strings.NewReplacer(`\`, `\\`, "'", "''").Replace(literal))
+}
+
+// parseInstanceRoleName parses the role full name into the instance full name and the role name.
+func parseInstanceRoleName(name string) (string, string, error) {
+	instanceName, roleName, ok := strings.Cut(name, "/roles/")
+	if !ok || roleName == "" || !strings.HasPrefix(instanceName, internal.InstanceNamePrefix) {
+		return "", "", errors.Errorf("invalid instance role name %s, expect instances/{instance}/roles/{role}", name)
+	}
+	return instanceName, roleName, nil
+}
+
+func quotePostgresIdentifier(identifier string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(identifier, `"`, `""`))
+}
+
+func quotePostgresLiteral(literal string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(literal, "'", "''"))
+}
+
+// getSCRAMSHA256Secret returns the SCRAM-SHA-256 secret for the password in the PostgreSQL format,
+// so the plain password never shows in the issue statement.
+func getSCRAMSHA256Secret(password string, salt []byte) (string, error) {
+	saltedPassword, err := pbkdf2.Key(sha256.New, password, salt, scramIterations, sha256.Size)
+	if err != nil {
+		return "", err
+	}
+	clientKey := hmac.New(sha256.New, saltedPassword)
+	clientKey.Write([]byte("Client Key"))
+	storedKey := sha256.Sum256(clientKey.Sum(nil))
+	serverKey := hmac.New(sha256.New, saltedPassword)
+	serverKey.Write([]byte("Server Key"))
+
+	return fmt.Sprintf(
+		"SCRAM-SHA-256$%d:%s$%s:%s",
+		scramIterations,
+		base64.StdEncoding.EncodeToString(salt),
+		base64.StdEncoding.EncodeToString(storedKey[:]),
+		base64.StdEncoding.EncodeToString(serverKey.Sum(nil)),
+	), nil
+}
+
+// getSHA256Crypt returns the SHA-256 crypt digest of the password in the crypt base64 encoding.
+func getSHA256Crypt(password string, salt []byte, rounds int) string {
+	key := []byte(password)
+
+	b := sha256.New()
+	b.Write(key)
+	b.Write(salt)
+	b.Write(key)
+	digestB := b.Sum(nil)
+
+	a := sha256.New()
+	a.Write(key)
+	a.Write(salt)
+	for i := len(key); i > 0; i -= sha256.Size {
+		a.Write(digestB[:min(i, sha256.Size)])
+	}
+	for i := len(key); i > 0; i >>= 1 {
+		if i&1 != 0 {
+			a.Write(digestB)
+		} else {
+			a.Write(key)
+		}
+	}
+	digestA := a.Sum(nil)
+
+	dp := sha256.New()
+	for range key {
+		dp.Write(key)
+	}
+	p := repeatDigest(dp.Sum(nil), len(key))
+
+	ds := sha256.New()
+	for i := 0; i < 16+int(digestA[0]); i++ {
+		ds.Write(salt)
+	}
+	s := repeatDigest(ds.Sum(nil), len(salt))
+
+	c := digestA
+	for i := 0; i < rounds; i++ {
+		h := sha256.New()
+		if i&1 != 0 {
+			h.Write(p)
+		} else {
+			h.Write(c)
+		}
+		if i%3 != 0 {
+			h.Write(s)
+		}
+		if i%7 != 0 {
+			h.Write(p)
+		}
+		if i&1 != 0 {
+			h.Write(c)
+		} else {
+			h.Write(p)
+		}
+		c = h.Sum(nil)
+	}
+
+	const alphabet = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	var sb strings.Builder
+	encode := func(b2, b1, b0 byte, n int) {
+		w := uint(b2)<<16 | uint(b1)<<8 | uint(b0)
+		for ; n > 0; n-- {
+			sb.WriteByte(alphabet[w&0x3f])
+			w >>= 6
+		}
+	}
+	for _, j := range [][3]int{{0, 10, 20}, {21, 1, 11}, {12, 22, 2}, {3, 13, 23}, {24, 4, 14}, {15, 25, 5}, {6, 16, 26}, {27, 7, 17}, {18, 28, 8}, {9, 19, 29}} {
+		encode(c[j[0]], c[j[1]], c[j[2]], 4)
+	}
+	encode(0, c[31], c[30], 3)
+	return sb.String()
+}
+
+// repeatDigest repeats the digest to the length.
+func repeatDigest(digest []byte, length int) []byte {
+	result := make([]byte, 0, length)
+	for len(result) < length {
+		result = append(result, digest[:min(length-len(result), len(digest))]...)
+	}
+	return result
+}
+
+// getCachingSHA2PasswordHash returns the MySQL caching_sha2_password hash for the password,
+// so the plain password never shows in the issue statement.
+func getCachingSHA2PasswordHash(password string, salt []byte) string {
+	return fmt.Sprintf("$A$%03d$%s%s", cachingSHA2Rounds/1000, salt, getSHA256Crypt(password, salt, cachingSHA2Rounds))
+}
+
+// getMySQLUserAuthOption returns the IDENTIFIED option for the MySQL user statement.
+func getMySQLUserAuthOption(password string) (string, error) {
+	if password == "" {
+		return "IDENTIFIED WITH caching_sha2_password BY ''", nil
+	}
+	const saltAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	salt := make([]byte, cachingSHA2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	for i := range salt {
+		salt[i] = saltAlphabet[int(salt[i])%len(saltAlphabet)]
+	}
+	return fmt.Sprintf("IDENTIFIED WITH caching_sha2_password AS 0x%s", hex.EncodeToString([]byte(getCachingSHA2PasswordHash(password, salt)))), nil
+}
+
+// getMySQLUserStatement returns the CREATE USER or ALTER USER statement with the auth option.
+func getMySQLUserStatement(action, user, host, authOption string) string {
+	if authOption == "" {
+		return fmt.Sprintf("%s USER %s;", action, quoteMySQLUser(user, host))
+	}
+	return fmt.Sprintf("%s USER %s %s;", action, quoteMySQLUser(user, host), authOption)
+}
+
+// getRolePasswordOption returns the PASSWORD option for the role statement.
+func getRolePasswordOption(password string) (string, error) {
+	if password == "" {
+		return "PASSWORD NULL", nil
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	secret, err := getSCRAMSHA256Secret(password, salt)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("PASSWORD %s", quotePostgresLiteral(secret)), nil
+}
+
+// getRoleValidUntilOption returns the VALID UNTIL option for the role statement, the empty value means never expire.
+func getRoleValidUntilOption(validUntil string) string {
+	if validUntil == "" {
+		validUntil = "infinity"
+	}
+	return fmt.Sprintf("VALID UNTIL %s", quotePostgresLiteral(validUntil))
+}
+
+// getCreateRoleAttributeOptions returns the options to grant the attributes and revoke all other attributes,
+// so the role doesn't keep the attributes granted by default, such as INHERIT.
+func getCreateRoleAttributeOptions(attributes []string) []string {
+	options := []string{}
+	for _, attribute := range instanceRoleAttributes {
+		if slices.Contains(attributes, attribute) {
+			options = append(options, attribute)
+		} else {
+			options = append(options, fmt.Sprintf("NO%s", attribute))
+		}
+	}
+	return options
+}
+
+// getRoleAttributeOptions returns the options to grant the new attributes and revoke the removed attributes.
+func getRoleAttributeOptions(oldAttributes, newAttributes []string) []string {
+	options := []string{}
+	for _, attribute := range instanceRoleAttributes {
+		inOld, inNew := slices.Contains(oldAttributes, attribute), slices.Contains(newAttributes, attribute)
+		switch {
+		case inNew && !inOld:
+			options = append(options, attribute)
+		case inOld && !inNew:
+			options = append(options, fmt.Sprintf("NO%s", attribute))
+		default:
+		}
+	}
+	return options
+}
+
+// getRoleStatement returns the CREATE ROLE or ALTER ROLE statement with the options.
+func getRoleStatement(action, roleName string, options []string) string {
+	if len(options) == 0 {
+		return fmt.Sprintf("%s ROLE %s;", action, quotePostgresIdentifier(roleName))
+	}
+	return fmt.Sprintf("%s ROLE %s WITH %s;", action, quotePostgresIdentifier(roleName), strings.Join(options, " "))
+}
+
+func getInstanceRoleAttributes(rawSet interface{}) []string {
+	attributes := []string{}
+	if set, ok := rawSet.(*schema.Set); ok {
+		for _, raw := range set.List() {
+			attributes = append(attributes, raw.(string))
+		}
+	}
+	return attributes
+}
+
+// getInstanceRoleEngine returns the instance engine, and checks the engine supports the instance role.
+func getInstanceRoleEngine(ctx context.Context, client api.Client, instanceName string) (v1pb.Engine, error) {
+	instance, err := client.GetInstance(ctx, instanceName)
+	if err != nil {
+		return v1pb.Engine_ENGINE_UNSPECIFIED, err
+	}
+	if !slices.Contains(instanceRoleEngines, instance.Engine) {
+		return v1pb.Engine_ENGINE_UNSPECIFIED, errors.Errorf("bytebase_instance_role only supports the POSTGRES and MYSQL instances, got %s", instance.Engine.String())
+	}
+	return instance.Engine, nil
+}
+
+// validateInstanceRoleConfig checks the config only uses the options supported by the engine.
+func validateInstanceRoleConfig(d *schema.ResourceData, engine v1pb.Engine) error {
+	if engine == v1pb.Engine_POSTGRES {
+		if d.Get("host").(string) != "" {
+			return errors.New("the host is only supported for the MYSQL instance")
+		}
+		return nil
+	}
+	if d.Get("database").(string) == "" {
+		return errors.Errorf("the database is required for the %s instance", engine.String())
+	}
+	if len(getInstanceRoleAttributes(d.Get("attributes"))) > 0 {
+		return errors.New("the attributes is only supported for the POSTGRES instance")
+	}
+	if d.Get("connection_limit").(int) != -1 {
+		return errors.New("the connection_limit is only supported for the POSTGRES instance")
+	}
+	if d.Get("valid_until").(string) != "" {
+		return errors.New("the valid_until is only supported for the POSTGRES instance")
+	}
+	return nil
+}
+
+// getInstanceRoleDatabaseName returns the database full name to run the role statements.
+func getInstanceRoleDatabaseName(d *schema.ResourceData, instanceName string) string {
+	return fmt.Sprintf("%s/%s%s", instanceName, internal.DatabaseIDPrefix, d.Get("database").(string))
+}
+
+// syncInstanceRole syncs the instance after the role statement is rolled out, so the instance roles are updated.
+// It returns nil if the role is not in the instance.
+func syncInstanceRole(ctx context.Context, client api.Client, instanceName, syncName string) (*v1pb.InstanceRole, error) {
+	if err := client.SyncInstanceSchema(ctx, instanceName); err != nil {
+		return nil, errors.Wrapf(err, "failed to sync instance %s", instanceName)
+	}
+	instance, err := client.GetInstance(ctx, instanceName)
+	if err != nil {
+		return nil, err
+	}
+	return findInstanceRole(instance, syncName), nil
+}
+
+func findInstanceRole(instance *v1pb.Instance, roleName string) *v1pb.InstanceRole {
+	for _, role := range instance.Roles {
+		if role.RoleName == roleName {
+			return role
+		}
+	}
+	return nil
+}
+
+func resourceInstanceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	instanceName := d.Get("instance").(string)
+	roleName := d.Get("role_name").(string)
+
+	engine, err := getInstanceRoleEngine(ctx, c, instanceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if engine == v1pb.Engine_POSTGRES && d.Get("database").(string) == "" {
+		if err := d.Set("database", "postgres"); err != nil {
+			return diag.Errorf("cannot set database for role: %s", err.Error())
+		}
+	}
+	if err := validateInstanceRoleConfig(d, engine); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var id, syncName, statement string
+	password := getWriteOnlyString(d, "password_wo")
+	if engine == v1pb.Engine_MYSQL {
+		host := d.Get("host").(string)
+		if host == "" {
+			host = defaultMySQLUserHost
+		}
+		authOption := ""
+		if password != "" {
+			option, err := getMySQLUserAuthOption(password)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			authOption = option
+		}
+		id = getInstanceRoleName(instanceName, getMySQLUserRoleName(roleName, host))
+		syncName = getMySQLUserSyncName(roleName, host)
+		statement = getMySQLUserStatement("CREATE", roleName, host, authOption)
+	} else {
+		options := getCreateRoleAttributeOptions(getInstanceRoleAttributes(d.Get("attributes")))
+		if password != "" {
+			option, err := getRolePasswordOption(password)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			options = append(options, option)
+		}
+		options = append(options, fmt.Sprintf("CONNECTION LIMIT %d", d.Get("connection_limit").(int)))
+		if validUntil := d.Get("valid_until").(string); validUntil != "" {
+			options = append(options, getRoleValidUntilOption(validUntil))
+		}
+		id = getInstanceRoleName(instanceName, roleName)
+		syncName = roleName
+		statement = getRoleStatement("CREATE", roleName, options)
+	}
+
+	issue, err := c.ChangeDatabase(ctx, d.Get("project").(string), getInstanceRoleDatabaseName(d, instanceName), fmt.Sprintf("[Terraform] Create role %s", roleName), statement)
+	if err != nil {
+		return diag.Errorf("failed to create the role %s with error: %v", roleName, err.Error())
+	}
+	if err := waitForIssueRollout(ctx, c, issue, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("role %s is not created, check the issue %s for details, error: %v", roleName, issue.Name, err.Error())
+	}
+	role, err := syncInstanceRole(ctx, c, instanceName, syncName)
+	if err != nil {
+		return diag.Errorf("failed to sync the role %s after the issue %s is rolled out, error: %v", roleName, issue.Name, err.Error())
+	}
+	if role == nil {
+		return diag.Errorf("role %s not found in instance %s after the issue %s is rolled out", roleName, instanceName, issue.Name)
+	}
+
+	d.SetId(id)
+	return resourceInstanceRoleRead(ctx, d, m)
+}
+
+// getInstanceRoleSyncName returns the role name synced from the instance and sets the role_name and host for the role ID.
+func getInstanceRoleSyncName(d *schema.ResourceData, engine v1pb.Engine, roleName string) (string, error) {
+	if engine != v1pb.Engine_MYSQL {
+		if err := d.Set("role_name", roleName); err != nil {
+			return "", errors.Errorf("cannot set role_name for role: %s", err.Error())
+		}
+		return roleName, nil
+	}
+	user, host, err := parseMySQLUserRoleName(roleName)
+	if err != nil {
+		return "", err
+	}
+	if err := d.Set("role_name", user); err != nil {
+		return "", errors.Errorf("cannot set role_name for role: %s", err.Error())
+	}
+	if err := d.Set("host", host); err != nil {
+		return "", errors.Errorf("cannot set host for role: %s", err.Error())
+	}
+	return getMySQLUserSyncName(user, host), nil
+}
+
+func resourceInstanceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	instanceName, roleName, err := parseInstanceRoleName(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	instance, err := c.GetInstance(ctx, instanceName)
+	if err != nil {
+		if internal.IsNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Resource %s not found, removing from state", instanceName))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	syncName, err := getInstanceRoleSyncName(d, instance.Engine, roleName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	role := findInstanceRole(instance, syncName)
+	if role == nil {
+		tflog.Warn(ctx, fmt.Sprintf("role %s not found in instance %s, remove it from the state", roleName, instanceName))
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("instance", instanceName); err != nil {
+		return diag.Errorf("cannot set instance for role: %s", err.Error())
+	}
+	connectionLimit := -1
+	if role.ConnectionLimit != nil {
+		connectionLimit = int(role.GetConnectionLimit())
+	}
+	if err := d.Set("connection_limit", connectionLimit); err != nil {
+		return diag.Errorf("cannot set connection_limit for role: %s", err.Error())
+	}
+	if err := d.Set("attribute", role.GetAttribute()); err != nil {
+		return diag.Errorf("cannot set attribute for role: %s", err.Error())
+	}
+	return nil
+}
+
+func resourceInstanceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	instanceName, roleName, err := parseInstanceRoleName(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	engine, err := getInstanceRoleEngine(ctx, c, instanceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := validateInstanceRoleConfig(d, engine); err != nil {
+		return diag.FromErr(err)
+	}
+
+	statement := ""
+	if engine == v1pb.Engine_MYSQL {
+		if d.HasChange("password_wo_version") {
+			option, err := getMySQLUserAuthOption(getWriteOnlyString(d, "password_wo"))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			statement = getMySQLUserStatement("ALTER", d.Get("role_name").(string), d.Get("host").(string), option)
+		}
+	} else {
+		options := []string{}
+		if d.HasChange("attributes") {
+			oldAttributes, newAttributes := d.GetChange("attributes")
+			options = append(options, getRoleAttributeOptions(getInstanceRoleAttributes(oldAttributes), getInstanceRoleAttributes(newAttributes))...)
+		}
+		if d.HasChange("password_wo_version") {
+			option, err := getRolePasswordOption(getWriteOnlyString(d, "password_wo"))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			options = append(options, option)
+		}
+		if d.HasChange("connection_limit") {
+			options = append(options, fmt.Sprintf("CONNECTION LIMIT %d", d.Get("connection_limit").(int)))
+		}
+		if d.HasChange("valid_until") {
+			options = append(options, getRoleValidUntilOption(d.Get("valid_until").(string)))
+		}
+		if len(options) > 0 {
+			statement = getRoleStatement("ALTER", roleName, options)
+		}
+	}
+
+	if statement != "" {
+		issue, err := c.ChangeDatabase(ctx, d.Get("project").(string), getInstanceRoleDatabaseName(d, instanceName), fmt.Sprintf("[Terraform] Alter role %s", roleName), statement)
+		if err != nil {
+			return diag.Errorf("failed to update the role %s with error: %v", roleName, err.Error())
+		}
+		if err := waitForIssueRollout(ctx, c, issue, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.Errorf("role %s is not updated, check the issue %s for details, error: %v", roleName, issue.Name, err.Error())
+		}
+		// The instance roles are updated by the schema sync after the issue is rolled out.
+		if err := c.SyncInstanceSchema(ctx, instanceName); err != nil {
+			return diag.Errorf("failed to sync instance %s after updating the role %s with error: %v", instanceName, roleName, err.Error())
+		}
+	}
+
+	return resourceInstanceRoleRead(ctx, d, m)
+}
+
+func resourceInstanceRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	instanceName, roleName, err := parseInstanceRoleName(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	engine, err := getInstanceRoleEngine(ctx, c, instanceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	statement := fmt.Sprintf("DROP ROLE %s;", quotePostgresIdentifier(roleName))
+	if engine == v1pb.Engine_MYSQL {
+		statement = fmt.Sprintf("DROP USER %s;", quoteMySQLUser(d.Get("role_name").(string), d.Get("host").(string)))
+	}
+	issue, err := c.ChangeDatabase(ctx, d.Get("project").(string), getInstanceRoleDatabaseName(d, instanceName), fmt.Sprintf("[Terraform] Drop role %s", roleName), statement)
+	if err != nil {
+		return diag.Errorf("failed to drop the role %s with error: %v", roleName, err.Error())
+	}
+	if err := waitForIssueRollout(ctx, c, issue, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.Errorf("role %s is not dropped, check the issue %s for details, error: %v", roleName, issue.Name, err.Error())
+	}
+	// The instance roles are updated by the schema sync after the issue is rolled out.
+	if err := c.SyncInstanceSchema(ctx, instanceName); err != nil {
+		return diag.Errorf("failed to sync instance %s after dropping the role %s with error: %v", instanceName, roleName, err.Error())
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceInstanceRoleImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	instanceName, roleName, err := parseInstanceRoleName(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	engine, err := getInstanceRoleEngine(ctx, m.(api.Client), instanceName)
+	if err != nil {
+		return nil, err
+	}
+	if engine == v1pb.Engine_MYSQL {
+		if _, _, err := parseMySQLUserRoleName(roleName); err != nil {
+			return nil, err
+		}
+	}
+	return []*schema.ResourceData{d}, nil
+}
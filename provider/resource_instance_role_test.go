@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func TestAccInstanceRole(t *testing.T) {
+	instanceID := "role-instance"
+	resourceName := "bytebase_instance_role.reader"
+
+	base := testAccCheckInstanceResource("role_instance", instanceID, "role instance", "POSTGRES", "environments/test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckInstanceRoleResource(base, 5),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("instances/%s/roles/reader", instanceID)),
+					resource.TestCheckResourceAttr(resourceName, "connection_limit", "5"),
+					resource.TestCheckResourceAttr(resourceName, "attributes.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckInstanceRoleResource(base, 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "connection_limit", "10"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"project", "database", "attributes", "valid_until", "password_wo_version"},
+			},
+		},
+	})
+}
+
+func TestAccInstanceRole_MySQL(t *testing.T) {
+	instanceID := "mysql-role-instance"
+	resourceName := "bytebase_instance_role.mysql_reader"
+
+	base := testAccCheckInstanceResource("mysql_role_instance", instanceID, "mysql role instance", "MYSQL", "environments/test")
+	getConfig := func(options string) string {
+		return fmt.Sprintf(`
+%s
+
+resource "bytebase_instance_role" "mysql_reader" {
+	instance  = bytebase_instance.mysql_role_instance.name
+	role_name = "reader"
+	host      = "10.0.0.%%"
+	project   = "projects/default"
+	database  = "default"
+	%s
+}
+`, base, options)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: getConfig(`
+	password_wo         = "secret"
+	password_wo_version = 1
+`),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("instances/%s/roles/reader@10.0.0.%%", instanceID)),
+					resource.TestCheckResourceAttr(resourceName, "role_name", "reader"),
+					resource.TestCheckResourceAttr(resourceName, "host", "10.0.0.%"),
+				),
+			},
+			{
+				Config: getConfig(`
+	password_wo         = "new-secret"
+	password_wo_version = 2
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "password_wo_version", "2"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"project", "database", "password_wo_version"},
+			},
+			{
+				Config:      getConfig(`attributes = ["LOGIN"]`),
+				ExpectError: regexp.MustCompile(`the attributes is only supported for the POSTGRES instance`),
+			},
+		},
+	})
+}
+
+func TestAccInstanceRole_UnsupportedEngine(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "bytebase_instance_role" "oracle_reader" {
+	instance  = bytebase_instance.oracle_role_instance.name
+	role_name = "reader"
+	project   = "projects/default"
+	database  = "default"
+}
+`, testAccCheckInstanceResource("oracle_role_instance", "oracle-role-instance", "oracle role instance", "ORACLE", "environments/test")),
+				ExpectError: regexp.MustCompile(`bytebase_instance_role only supports the POSTGRES and MYSQL instances, got ORACLE`),
+			},
+		},
+	})
+}
+
+func testAccCheckInstanceRoleResource(base string, connectionLimit int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "bytebase_instance_role" "reader" {
+	instance            = bytebase_instance.role_instance.name
+	role_name           = "reader"
+	project             = "projects/default"
+	database            = "default"
+	password_wo         = "secret"
+	password_wo_version = 1
+	connection_limit    = %d
+	valid_until         = "2030-01-01 00:00:00+00"
+	attributes          = ["LOGIN"]
+}
+`, base, connectionLimit)
+}
+
+func TestGetSCRAMSHA256Secret(t *testing.T) {
+	// The test vector in RFC 7677.
+	salt, err := base64.StdEncoding.DecodeString("W22ZaJ0SNY7soEsUEjb6gQ==")
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret, err := getSCRAMSHA256Secret("pencil", salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "SCRAM-SHA-256$4096:W22ZaJ0SNY7soEsUEjb6gQ==$WG5d8oPm3OtcPnkdi4Uo7BkeZkBFzpcXkuLmtbsT4qY=:wfPLwcE6nTWhTAmQ7tl2KeoiWGPlZqQxSrmfPwDl2dU="
+	if secret != want {
+		t.Errorf("getSCRAMSHA256Secret() = %q, want %q", secret, want)
+	}
+}
+
+func TestGetCachingSHA2PasswordHash(t *testing.T) {
+	// The SHA-256 crypt test vector, the same as openssl passwd -5 -salt saltstring "Hello world!".
+	if got, want := getSHA256Crypt("Hello world!", []byte("saltstring"), cachingSHA2Rounds), "5B8vYYiY.CVt1RlTTf8KbXBH3hsxY/GNooZaBBGWEc5"; got != want {
+		t.Errorf("getSHA256Crypt() = %q, want %q", got, want)
+	}
+
+	salt := []byte("abcdefghijklmnopqrst")
+	hash := getCachingSHA2PasswordHash("secret", salt)
+	if want := "$A$005$abcdefghijklmnopqrst"; !strings.HasPrefix(hash, want) {
+		t.Errorf("getCachingSHA2PasswordHash() = %q, want prefix %q", hash, want)
+	}
+	if got, want := len(hash), 70; got != want {
+		t.Errorf("getCachingSHA2PasswordHash() length = %d, want %d", got, want)
+	}
+
+	option, err := getMySQLUserAuthOption("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(option, "secret") {
+		t.Errorf("getMySQLUserAuthOption() = %q contains the plain password", option)
+	}
+	if got, want := getMySQLUserStatement("CREATE", "it's", "%", ""), `CREATE USER 'it''s'@'%';`; got != want {
+		t.Errorf("getMySQLUserStatement() = %q, want %q", got, want)
+	}
+}
+
+func TestGetCreateRoleAttributeOptions(t *testing.T) {
+	options := getCreateRoleAttributeOptions([]string{"LOGIN"})
+	if want := []string{"NOSUPERUSER", "NOCREATEDB", "NOCREATEROLE", "NOINHERIT", "LOGIN", "NOREPLICATION", "NOBYPASSRLS"}; !slices.Equal(options, want) {
+		t.Errorf("getCreateRoleAttributeOptions() = %v, want %v", options, want)
+	}
+}
+
+func TestGetRoleStatement(t *testing.T) {
+	options := getRoleAttributeOptions([]string{"LOGIN", "CREATEDB"}, []string{"LOGIN", "CREATEROLE"})
+	if want := []string{"NOCREATEDB", "CREATEROLE"}; !slices.Equal(options, want) {
+		t.Fatalf("getRoleAttributeOptions() = %v, want %v", options, want)
+	}
+	options = append(options, "CONNECTION LIMIT 5", getRoleValidUntilOption(""))
+
+	tests := []struct {
+		action   string
+		roleName string
+		options  []string
+		want     string
+	}{
+		{
+			action:   "ALTER",
+			roleName: "reader",
+			options:  options,
+			want:     `ALTER ROLE "reader" WITH NOCREATEDB CREATEROLE CONNECTION LIMIT 5 VALID UNTIL 'infinity';`,
+		},
+		{
+			action:   "CREATE",
+			roleName: `my"role`,
+			want:     `CREATE ROLE "my""role";`,
+		},
+	}
+	for _, test := range tests {
+		if got := getRoleStatement(test.action, test.roleName, test.options); got != test.want {
+			t.Errorf("getRoleStatement() = %q, want %q", got, test.want)
+		}
+	}
+}
+
+func TestParseInstanceRoleName(t *testing.T) {
+	instanceName, roleName, err := parseInstanceRoleName(getInstanceRoleName("instances/i", "reader"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if instanceName != "instances/i" || roleName != "reader" {
+		t.Errorf("parseInstanceRoleName() = %q, %q", instanceName, roleName)
+	}
+	if _, _, err := parseInstanceRoleName("instances/i"); err == nil {
+		t.Errorf("parseInstanceRoleName() expect error for the instance name")
+	}
+}
+
+func TestParseMySQLUserRoleName(t *testing.T) {
+	user, host, err := parseMySQLUserRoleName(getMySQLUserRoleName("app@corp", "%"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user != "app@corp" || host != "%" {
+		t.Errorf("parseMySQLUserRoleName() = %q, %q", user, host)
+	}
+	for _, roleName := range []string{"app", "@%", "app@"} {
+		if _, _, err := parseMySQLUserRoleName(roleName); err == nil {
+			t.Errorf("parseMySQLUserRoleName(%q) expect error", roleName)
+		}
+	}
+}
@@ -4,11 +4,17 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
 
 	"github.com/bytebase/terraform-provider-bytebase/api"
 	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
@@ -63,6 +69,129 @@ func TestAccInstance(t *testing.T) {
 	})
 }
 
+func TestAccInstance_WaitForSync(t *testing.T) {
+	identifier := "sync_instance"
+	resourceName := fmt.Sprintf("bytebase_instance.%s", identifier)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "bytebase_instance" "%s" {
+					resource_id   = "test-sync-instance"
+					title         = "test sync instance"
+					engine        = "MYSQL"
+					environment   = "environments/test"
+					wait_for_sync = true
+
+					timeouts {
+						create = "1m"
+					}
+
+					data_sources {
+						id       = "admin data source"
+						type     = "ADMIN"
+						username = "bytebase"
+						host     = "127.0.0.1"
+						port     = "3306"
+					}
+
+					deletion_protection = false
+				}
+				`, identifier),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "wait_for_sync", "true"),
+					resource.TestCheckResourceAttr(resourceName, "databases.#", "4"),
+					resource.TestCheckResourceAttrSet(resourceName, "last_sync_time"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "databases.*", "instances/test-sync-instance/databases/default"),
+				),
+			},
+		},
+	})
+}
+
+func TestApplyDataSourceCredentials(t *testing.T) {
+	credential := func(id string, values map[string]string) cty.Value {
+		attrs := map[string]cty.Value{
+			"id": cty.StringVal(id),
+		}
+		for _, key := range dataSourceCredentialKeys {
+			attrs[key] = cty.NullVal(cty.String)
+			if v, ok := values[key]; ok {
+				attrs[key] = cty.StringVal(v)
+			}
+			attrs[fmt.Sprintf("%s_version", key)] = cty.NullVal(cty.Number)
+		}
+		return cty.ObjectVal(attrs)
+	}
+	rawConfig := func(credentials ...cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"data_source_credentials": cty.ListVal(credentials),
+		})
+	}
+
+	dataSourceList := []*v1pb.DataSource{
+		{Id: "admin", Type: v1pb.DataSourceType_ADMIN},
+		{
+			Id:   "read-only",
+			Type: v1pb.DataSourceType_READ_ONLY,
+			IamExtension: &v1pb.DataSource_AwsCredential{
+				AwsCredential: &v1pb.DataSource_AWSCredential{AccessKeyId: "key"},
+			},
+		},
+	}
+	if err := applyDataSourceCredentials(rawConfig(
+		credential("admin", map[string]string{"password_wo": "secret", "ssh_private_key_wo": "ssh-key"}),
+		credential("read-only", map[string]string{"credential_secret_wo": "aws-secret"}),
+	), dataSourceList); err != nil {
+		t.Fatalf("applyDataSourceCredentials() error = %v", err)
+	}
+	if got, want := dataSourceList[0].Password, "secret"; got != want {
+		t.Fatalf("admin password = %q, want %q", got, want)
+	}
+	if got, want := dataSourceList[0].SshPrivateKey, "ssh-key"; got != want {
+		t.Fatalf("admin ssh private key = %q, want %q", got, want)
+	}
+	if got, want := dataSourceList[1].GetAwsCredential().SecretAccessKey, "aws-secret"; got != want {
+		t.Fatalf("read-only aws secret access key = %q, want %q", got, want)
+	}
+
+	if err := applyDataSourceCredentials(rawConfig(
+		credential("admin", map[string]string{"password_wo": "secret"}),
+	), []*v1pb.DataSource{{Id: "admin", Password: "plain"}}); err == nil {
+		t.Fatal("applyDataSourceCredentials() expects error for both password and password_wo")
+	}
+	if err := applyDataSourceCredentials(rawConfig(
+		credential("unknown", map[string]string{"password_wo": "secret"}),
+	), []*v1pb.DataSource{{Id: "admin"}}); err == nil {
+		t.Fatal("applyDataSourceCredentials() expects error for unknown data source id")
+	}
+	if err := applyDataSourceCredentials(cty.NullVal(cty.DynamicPseudoType), []*v1pb.DataSource{{
+		Id: "admin",
+		IamExtension: &v1pb.DataSource_GcpCredential{
+			GcpCredential: &v1pb.DataSource_GCPCredential{},
+		},
+	}}); err != nil {
+		t.Fatalf("applyDataSourceCredentials() with null config error = %v", err)
+	}
+	if err := applyDataSourceCredentials(cty.ObjectVal(map[string]cty.Value{
+		"data_source_credentials": cty.NullVal(cty.List(cty.DynamicPseudoType)),
+	}), []*v1pb.DataSource{{
+		Id: "admin",
+		IamExtension: &v1pb.DataSource_GcpCredential{
+			GcpCredential: &v1pb.DataSource_GCPCredential{},
+		},
+	}}); err == nil {
+		t.Fatal("applyDataSourceCredentials() expects error for missing gcp credential content")
+	}
+}
+
 func TestAccInstance_InvalidInput(t *testing.T) {
 	identifier := "another_instance"
 	engine := "POSTGRES"
@@ -98,6 +227,8 @@ func TestAccInstance_InvalidInput(t *testing.T) {
 						host  = "127.0.0.1"
 						port  = "3306"
 					}
+
+					deletion_protection = false
 				}
 				`,
 				ExpectError: regexp.MustCompile(`data source "ADMIN" is required`),
@@ -116,6 +247,8 @@ func TestAccInstance_InvalidInput(t *testing.T) {
 						host  = "127.0.0.1"
 						port  = 5432
 					}
+
+					deletion_protection = false
 				}
 				`,
 				ExpectError: regexp.MustCompile(`expected data_sources.0.type to be one of`),
@@ -140,10 +273,192 @@ func TestAccInstance_InvalidInput(t *testing.T) {
 						host  = "127.0.0.1"
 						port  = 5432
 					}
+
+					deletion_protection = false
 				}
 				`,
 				ExpectError: regexp.MustCompile(`duplicate data source type ADMIN`),
 			},
+			// Unreachable data source
+			{
+				Config: `
+				resource "bytebase_instance" "test_instance" {
+					resource_id         = "test-instance"
+					engine              = "POSTGRES"
+					title               = "test instance"
+					environment         = "environments/test"
+					validate_connection = true
+					data_sources {
+						id    = "admin data source"
+						type  = "ADMIN"
+						host  = "127.0.0.1"
+						port  = 5432
+					}
+					data_sources {
+						id    = "read-only data source"
+						type  = "READ_ONLY"
+						host  = "replica.invalid"
+						port  = 5432
+					}
+
+					deletion_protection = false
+				}
+				`,
+				ExpectError: regexp.MustCompile(`failed to connect the data source "read-only data source" \(READ_ONLY\)`),
+			},
+		},
+	})
+}
+
+func TestAccInstance_ValidateConnectionOnUpdate(t *testing.T) {
+	identifier := "validate_instance"
+	getConfig := func(replicaHost string) string {
+		return fmt.Sprintf(`
+		resource "bytebase_instance" "%s" {
+			resource_id         = "validate-instance"
+			engine              = "POSTGRES"
+			title               = "validate instance"
+			environment         = "environments/test"
+			validate_connection = true
+			data_sources {
+				id    = "admin data source"
+				type  = "ADMIN"
+				host  = "127.0.0.1"
+				port  = 5432
+			}
+			data_sources {
+				id    = "read-only data source"
+				type  = "READ_ONLY"
+				host  = "%s"
+				port  = 5432
+			}
+
+			deletion_protection = false
+		}
+		`, identifier, replicaHost)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: getConfig("127.0.0.2"),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists(fmt.Sprintf("bytebase_instance.%s", identifier)),
+					resource.TestCheckResourceAttr(fmt.Sprintf("bytebase_instance.%s", identifier), "data_sources.#", "2"),
+				),
+			},
+			// the changed data source in the existing instance is validated
+			{
+				Config:      getConfig("replica.invalid"),
+				ExpectError: regexp.MustCompile(`failed to connect the data source "read-only data source" \(READ_ONLY\) in instance instances/validate-instance`),
+			},
+		},
+	})
+}
+
+func TestGetDataSourceValidateUpdateMasks(t *testing.T) {
+	updateMasks := getDataSourceValidateUpdateMasks(&v1pb.DataSource{
+		Id:   "admin",
+		Type: v1pb.DataSourceType_ADMIN,
+		Host: "127.0.0.1",
+	})
+	if !slices.Contains(updateMasks, "host") || !slices.Contains(updateMasks, "port") {
+		t.Fatalf("update masks %v should contain host and port", updateMasks)
+	}
+	for _, mask := range []string{"id", "type", "password", "azure_credential"} {
+		if slices.Contains(updateMasks, mask) {
+			t.Fatalf("update masks %v should not contain %s", updateMasks, mask)
+		}
+	}
+
+	updateMasks = getDataSourceValidateUpdateMasks(&v1pb.DataSource{
+		Id:       "admin",
+		Type:     v1pb.DataSourceType_ADMIN,
+		Password: "secret",
+	})
+	if !slices.Contains(updateMasks, "password") {
+		t.Fatalf("update masks %v should contain the configured password", updateMasks)
+	}
+}
+
+func TestGetDatabaseSyncError(t *testing.T) {
+	lastSyncTime := time.Now()
+	databases := []*v1pb.Database{
+		{
+			Name:               "instances/sync-instance/databases/synced",
+			SuccessfulSyncTime: timestamppb.New(lastSyncTime.Add(time.Minute)),
+		},
+		{
+			Name:               "instances/sync-instance/databases/stale",
+			SuccessfulSyncTime: timestamppb.New(lastSyncTime.Add(-time.Hour)),
+			SyncError:          "old sync error",
+		},
+		{
+			Name:      "instances/sync-instance/databases/never-synced",
+			SyncError: "old sync error",
+		},
+	}
+	if err := getDatabaseSyncError(databases, lastSyncTime); err != nil {
+		t.Fatalf("the old sync errors should be ignored, got %v", err)
+	}
+
+	databases[0].SyncError = "new sync error"
+	err := getDatabaseSyncError(databases, lastSyncTime)
+	if err == nil || err.Error() != "database instances/sync-instance/databases/synced sync failed: new sync error" {
+		t.Fatalf("expect the new sync error, got %v", err)
+	}
+}
+
+func TestAccInstance_PurgeOnDestroy(t *testing.T) {
+	identifier := "purge_instance"
+	resourceName := fmt.Sprintf("bytebase_instance.%s", identifier)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: func(*terraform.State) error {
+			c, ok := testAccProvider.Meta().(api.Client)
+			if !ok {
+				return errors.Errorf("cannot get the api client")
+			}
+			if _, err := c.GetInstance(context.Background(), "instances/purge-instance"); err == nil {
+				return errors.Errorf("instance instances/purge-instance is not purged")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "bytebase_instance" "%s" {
+					resource_id      = "purge-instance"
+					title            = "purge instance"
+					engine           = "POSTGRES"
+					environment      = "environments/test"
+					purge_on_destroy = true
+
+					data_sources {
+						id       = "admin data source"
+						type     = "ADMIN"
+						username = "bytebase"
+						host     = "127.0.0.1"
+						port     = "5432"
+					}
+
+					deletion_protection = false
+				}
+				`, identifier),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "purge_on_destroy", "true"),
+				),
+			},
 		},
 	})
 }
@@ -159,7 +474,7 @@ func testAccCheckInstanceDestroy(s *terraform.State) error {
 			continue
 		}
 
-		if err := c.DeleteInstance(context.Background(), rs.Primary.ID); err != nil {
+		if err := c.DeleteInstance(context.Background(), rs.Primary.ID, false /* purge */); err != nil {
 			return err
 		}
 	}
@@ -196,6 +511,8 @@ func testAccCheckInstanceResourceWithLabels(identifier, id, name, engine, env, l
 			host     = "127.0.0.1"
 			port     = "3306"
 		}
+
+		deletion_protection = false
 	}
 	`, identifier, id, name, engine, env, labels)
 }
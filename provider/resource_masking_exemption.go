@@ -0,0 +1,433 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func resourceMaskingExemption() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage a single exemption in the project MASKING_EXEMPTION policy without touching the exemptions written by others. The exemption is matched by its members and condition, the create fails if an exemption with the same members and condition already exists, unless adopt_existing is true. Once the expire_timestamp passes, the plan marks the exemption inactive and the apply removes it from the policy. Don't use it with the masking_exemption_policy in the bytebase_policy resource, which manages the whole policy. The policy has no version check, so don't apply the masking exemptions from concurrent Terraform runs, one run may overwrite the exemptions written by the other.",
+		CreateContext: resourceMaskingExemptionCreate,
+		ReadContext:   resourceMaskingExemptionRead,
+		UpdateContext: resourceMaskingExemptionUpdate,
+		DeleteContext: resourceMaskingExemptionDelete,
+		CustomizeDiff: validateMaskingExemptionExpiration,
+		Schema: map[string]*schema.Schema{
+			"parent": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateDiagFunc: internal.ResourceNameValidation(
+					fmt.Sprintf("^%s%s$", internal.ProjectNamePrefix, internal.ResourceIDPattern),
+				),
+				Description: "The project full name in projects/{project} format.",
+			},
+			"members": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:        schema.TypeString,
+					Description: "The member in user:{email}, group:{email}, serviceAccount:{email} or workloadIdentity:{email} format.",
+					ValidateDiagFunc: internal.ResourceNameValidation(
+						"^user:",
+						"^group:",
+						"^serviceAccount:",
+						"^workloadIdentity:",
+					),
+				},
+				Description: "The members to exempt from the masking.",
+			},
+			"database": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateDiagFunc: internal.ResourceNameValidation(
+					// database name format
+					fmt.Sprintf(`^%s%s/%s\S+$`, internal.InstanceNamePrefix, internal.ResourceIDPattern, internal.DatabaseIDPrefix),
+				),
+				Description: "The database full name in instances/{instance}/databases/{database} format. Exempt all databases in the project if not set.",
+			},
+			"schema": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"database"},
+				Description:  "The schema name in the database.",
+			},
+			"table": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"database"},
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The table name in the database. Exempt all tables if not set.",
+			},
+			"columns": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				RequiredWith: []string{"table"},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Description: "The column names in the table. Exempt all columns if not set.",
+			},
+			"reason": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The reason for the masking exemption.",
+			},
+			"expire_timestamp": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "The expiration timestamp in YYYY-MM-DDThh:mm:ssZ format. The exemption never expires if not set.",
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the exemption is in the policy. It's planned to false once the expire_timestamp passes, and the apply removes the expired exemption from the policy.",
+			},
+			"policy": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The masking exemption policy full name.",
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Adopt the existing exemption with the same members and condition on create, for example the exemption granted in the Bytebase UI. If false, the create fails when the exemption already exists.",
+			},
+		},
+	}
+}
+
+// maskingExemptionKeys are the keys to build the exemption by convertToV1Exemptions.
+var maskingExemptionKeys = []string{"database", "schema", "table", "columns", "members", "reason", "expire_timestamp"}
+
+// convertToMaskingExemption converts the exemption from the values returned by the get function.
+// Use d.Get for the planned values, or the old values from d.GetChange to find the exemption in the state.
+func convertToMaskingExemption(get func(key string) interface{}) (*v1pb.MaskingExemptionPolicy_Exemption, error) {
+	raw := map[string]interface{}{
+		"raw_expression": "",
+	}
+	for _, key := range maskingExemptionKeys {
+		raw[key] = get(key)
+	}
+	exemptions, err := convertToV1Exemptions(raw)
+	if err != nil {
+		return nil, err
+	}
+	return exemptions[0], nil
+}
+
+// getOldMaskingExemptionValue returns the getter for the old values in the state.
+func getOldMaskingExemptionValue(d *schema.ResourceData) func(key string) interface{} {
+	return func(key string) interface{} {
+		old, _ := d.GetChange(key)
+		return old
+	}
+}
+
+// getSortedMembers returns the sorted copy of the exemption members.
+func getSortedMembers(exemption *v1pb.MaskingExemptionPolicy_Exemption) []string {
+	members := slices.Clone(exemption.GetMembers())
+	slices.Sort(members)
+	return members
+}
+
+// isSameMaskingExemption returns true if the exemptions have the same members and condition expression.
+// The reason is not compared, so it can be updated in place.
+func isSameMaskingExemption(a, b *v1pb.MaskingExemptionPolicy_Exemption) bool {
+	return a.GetCondition().GetExpression() == b.GetCondition().GetExpression() &&
+		slices.Equal(getSortedMembers(a), getSortedMembers(b))
+}
+
+// getMaskingExemptionPolicyName returns the MASKING_EXEMPTION policy name for the project.
+func getMaskingExemptionPolicyName(parent string) string {
+	return fmt.Sprintf("%s/%s%s", parent, internal.PolicyNamePrefix, v1pb.PolicyType_MASKING_EXEMPTION.String())
+}
+
+// getMaskingExemptionID returns the masking exemption id in {policy}/exemptions/{hash} format.
+// The hash is calculated by the members and the condition expression, which identify the exemption.
+func getMaskingExemptionID(policyName string, exemption *v1pb.MaskingExemptionPolicy_Exemption) string {
+	hash := internal.ToHashcodeInt(fmt.Sprintf("%s|%s", strings.Join(getSortedMembers(exemption), ","), exemption.GetCondition().GetExpression()))
+	return fmt.Sprintf("%s/exemptions/%d", policyName, hash)
+}
+
+// parseMaskingExemptionID parses the policy name from the masking exemption id.
+func parseMaskingExemptionID(id string) (string, error) {
+	policyName, hash, ok := strings.Cut(id, "/exemptions/")
+	if !ok || hash == "" {
+		return "", errors.Errorf("invalid masking exemption id %s, expect {policy}/exemptions/{hash}", id)
+	}
+	return policyName, nil
+}
+
+// isMaskingExemptionExpired returns true if the expire timestamp is not after now.
+func isMaskingExemptionExpired(expireTimestamp string, now time.Time) bool {
+	if expireTimestamp == "" {
+		return false
+	}
+	expireTime, err := time.Parse(time.RFC3339, expireTimestamp)
+	if err != nil {
+		return false
+	}
+	return !now.Before(expireTime)
+}
+
+// validateMaskingExemptionExpiration plans the active by the expire_timestamp.
+// The new exemption cannot be expired, and the existing exemption is planned to inactive once it expires.
+func validateMaskingExemptionExpiration(ctx context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("expire_timestamp") {
+		return diff.SetNewComputed("active")
+	}
+	expireTimestamp := diff.Get("expire_timestamp").(string)
+	expired := isMaskingExemptionExpired(expireTimestamp, time.Now())
+	if diff.Id() == "" {
+		if expired {
+			return errors.Errorf("the expire_timestamp %s is in the past", expireTimestamp)
+		}
+		return diff.SetNew("active", true)
+	}
+	if active, _ := diff.GetChange("active"); active.(bool) && expired {
+		tflog.Warn(ctx, fmt.Sprintf("the masking exemption %s expired at %s, it will be removed from the policy", diff.Id(), expireTimestamp))
+	}
+	return diff.SetNew("active", !expired)
+}
+
+// getMaskingExemptions returns the exemptions in the masking exemption policy, the missing policy has no exemptions.
+func getMaskingExemptions(ctx context.Context, client api.Client, policyName string) ([]*v1pb.MaskingExemptionPolicy_Exemption, error) {
+	policy, err := client.GetPolicy(ctx, policyName)
+	if err != nil {
+		if internal.IsNotFoundError(err) {
+			return []*v1pb.MaskingExemptionPolicy_Exemption{}, nil
+		}
+		return nil, err
+	}
+	return policy.GetMaskingExemptionPolicy().GetExemptions(), nil
+}
+
+func equalMaskingExemptions(a, b []*v1pb.MaskingExemptionPolicy_Exemption) bool {
+	return slices.EqualFunc(a, b, func(x, y *v1pb.MaskingExemptionPolicy_Exemption) bool {
+		return proto.Equal(x, y)
+	})
+}
+
+// patchMaskingExemptionPolicy reads the latest masking exemption policy, applies the patch and updates the policy.
+// The policy is not updated if the patch makes no change.
+// Same as patchMaskingRulePolicy, the update overwrites the exemptions written by others between the read and the update,
+// so the exemptions of the same project should not be applied from concurrent runs.
+func patchMaskingExemptionPolicy(ctx context.Context, client api.Client, policyName string, patch func(exemptions []*v1pb.MaskingExemptionPolicy_Exemption) ([]*v1pb.MaskingExemptionPolicy_Exemption, error)) error {
+	maskingPolicyMutex.Lock()
+	defer maskingPolicyMutex.Unlock()
+
+	current, err := getMaskingExemptions(ctx, client, policyName)
+	if err != nil {
+		return err
+	}
+	exemptions := []*v1pb.MaskingExemptionPolicy_Exemption{}
+	for _, exemption := range current {
+		exemptions = append(exemptions, proto.Clone(exemption).(*v1pb.MaskingExemptionPolicy_Exemption))
+	}
+
+	exemptions, err = patch(exemptions)
+	if err != nil {
+		return err
+	}
+	if equalMaskingExemptions(exemptions, current) {
+		return nil
+	}
+
+	_, err = client.UpsertPolicy(ctx, &v1pb.Policy{
+		Name: policyName,
+		Type: v1pb.PolicyType_MASKING_EXEMPTION,
+		Policy: &v1pb.Policy_MaskingExemptionPolicy{
+			MaskingExemptionPolicy: &v1pb.MaskingExemptionPolicy{
+				Exemptions: exemptions,
+			},
+		},
+	}, []string{"masking_exemption_policy"})
+	return err
+}
+
+// getMaskingExemptionExistsError returns the actionable error for the existing exemption if the adoption is disabled.
+func getMaskingExemptionExistsError(policyName string) error {
+	return errors.Errorf("the masking exemption with the same members and condition already exists in policy %s, set adopt_existing = true to adopt it", policyName)
+}
+
+func resourceMaskingExemptionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	policyName := getMaskingExemptionPolicyName(d.Get("parent").(string))
+	exemption, err := convertToMaskingExemption(d.Get)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if expireTimestamp := d.Get("expire_timestamp").(string); isMaskingExemptionExpired(expireTimestamp, time.Now()) {
+		return diag.Errorf("the expire_timestamp %s is in the past", expireTimestamp)
+	}
+
+	adoptExisting := d.Get("adopt_existing").(bool)
+	if err := patchMaskingExemptionPolicy(ctx, c, policyName, func(exemptions []*v1pb.MaskingExemptionPolicy_Exemption) ([]*v1pb.MaskingExemptionPolicy_Exemption, error) {
+		index := slices.IndexFunc(exemptions, func(e *v1pb.MaskingExemptionPolicy_Exemption) bool {
+			return isSameMaskingExemption(e, exemption)
+		})
+		if index < 0 {
+			return append(exemptions, exemption), nil
+		}
+		if !adoptExisting {
+			return nil, getMaskingExemptionExistsError(policyName)
+		}
+		tflog.Info(ctx, fmt.Sprintf("adopt the existing masking exemption with the same members and condition in policy %s", policyName))
+		exemptions[index] = exemption
+		return exemptions, nil
+	}); err != nil {
+		return diag.Errorf("failed to create masking exemption in policy %s with error: %v", policyName, err.Error())
+	}
+
+	d.SetId(getMaskingExemptionID(policyName, exemption))
+	return resourceMaskingExemptionRead(ctx, d, m)
+}
+
+func resourceMaskingExemptionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	policyName, err := parseMaskingExemptionID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	exemption, err := convertToMaskingExemption(d.Get)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	exemptions := []*v1pb.MaskingExemptionPolicy_Exemption{}
+	policy, err := c.GetPolicy(ctx, policyName)
+	if err != nil {
+		if !internal.IsNotFoundError(err) {
+			return diag.FromErr(err)
+		}
+	} else {
+		exemptions = policy.GetMaskingExemptionPolicy().GetExemptions()
+	}
+
+	if err := d.Set("policy", policyName); err != nil {
+		return diag.Errorf("cannot set policy for masking exemption: %s", err.Error())
+	}
+
+	index := slices.IndexFunc(exemptions, func(e *v1pb.MaskingExemptionPolicy_Exemption) bool {
+		return isSameMaskingExemption(e, exemption)
+	})
+	if index < 0 {
+		if isMaskingExemptionExpired(d.Get("expire_timestamp").(string), time.Now()) {
+			// The expired exemption is removed from the policy, keep it in the state as inactive.
+			if err := d.Set("active", false); err != nil {
+				return diag.Errorf("cannot set active for masking exemption: %s", err.Error())
+			}
+			return nil
+		}
+		tflog.Warn(ctx, fmt.Sprintf("masking exemption %s not found in policy %s, remove it from the state", d.Id(), policyName))
+		d.SetId("")
+		return nil
+	}
+
+	existing := exemptions[index]
+	reason := existing.GetCondition().GetTitle()
+	if reason == "" {
+		reason = existing.GetCondition().GetDescription()
+	}
+	if err := d.Set("reason", reason); err != nil {
+		return diag.Errorf("cannot set reason for masking exemption: %s", err.Error())
+	}
+	if err := d.Set("active", true); err != nil {
+		return diag.Errorf("cannot set active for masking exemption: %s", err.Error())
+	}
+	return nil
+}
+
+func resourceMaskingExemptionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	policyName, err := parseMaskingExemptionID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	oldExemption, err := convertToMaskingExemption(getOldMaskingExemptionValue(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	exemption, err := convertToMaskingExemption(d.Get)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	active := !isMaskingExemptionExpired(d.Get("expire_timestamp").(string), time.Now())
+
+	adoptExisting := d.Get("adopt_existing").(bool)
+	if err := patchMaskingExemptionPolicy(ctx, c, policyName, func(exemptions []*v1pb.MaskingExemptionPolicy_Exemption) ([]*v1pb.MaskingExemptionPolicy_Exemption, error) {
+		index := slices.IndexFunc(exemptions, func(e *v1pb.MaskingExemptionPolicy_Exemption) bool {
+			return isSameMaskingExemption(e, oldExemption)
+		})
+		if index >= 0 {
+			exemptions = slices.Delete(exemptions, index, index+1)
+		}
+		if !active {
+			return exemptions, nil
+		}
+		if existing := slices.IndexFunc(exemptions, func(e *v1pb.MaskingExemptionPolicy_Exemption) bool {
+			return isSameMaskingExemption(e, exemption)
+		}); existing >= 0 {
+			// Another exemption has the same members and condition as the updated one.
+			if !adoptExisting {
+				return nil, getMaskingExemptionExistsError(policyName)
+			}
+			exemptions[existing] = exemption
+			return exemptions, nil
+		}
+		if index >= 0 {
+			// Keep the exemption at its position.
+			return slices.Insert(exemptions, index, exemption), nil
+		}
+		return append(exemptions, exemption), nil
+	}); err != nil {
+		return diag.Errorf("failed to update masking exemption %s with error: %v", d.Id(), err.Error())
+	}
+
+	d.SetId(getMaskingExemptionID(policyName, exemption))
+	return resourceMaskingExemptionRead(ctx, d, m)
+}
+
+func resourceMaskingExemptionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	policyName, err := parseMaskingExemptionID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	exemption, err := convertToMaskingExemption(d.Get)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := patchMaskingExemptionPolicy(ctx, c, policyName, func(exemptions []*v1pb.MaskingExemptionPolicy_Exemption) ([]*v1pb.MaskingExemptionPolicy_Exemption, error) {
+		return slices.DeleteFunc(exemptions, func(e *v1pb.MaskingExemptionPolicy_Exemption) bool {
+			return isSameMaskingExemption(e, exemption)
+		}), nil
+	}); err != nil {
+		return diag.Errorf("failed to delete masking exemption %s with error: %v", d.Id(), err.Error())
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/type/expr"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func TestAccMaskingExemption(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMaskingExemptionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckMaskingExemptionResource("2099-01-01T00:00:00Z", "audit"),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists("bytebase_masking_exemption.ed"),
+					internal.TestCheckResourceExists("bytebase_masking_exemption.dev"),
+					resource.TestCheckResourceAttr("bytebase_masking_exemption.ed", "active", "true"),
+					resource.TestCheckResourceAttr("bytebase_masking_exemption.ed", "reason", "audit"),
+					resource.TestCheckResourceAttr("bytebase_masking_exemption.ed", "policy", "projects/project-sample/policies/MASKING_EXEMPTION"),
+					resource.TestCheckResourceAttr("data.bytebase_policy.masking_exemption", "masking_exemption_policy.0.exemptions.#", "2"),
+				),
+			},
+			// update the reason in place
+			{
+				Config: testAccCheckMaskingExemptionResource("2099-01-01T00:00:00Z", "quarterly audit"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bytebase_masking_exemption.ed", "reason", "quarterly audit"),
+					resource.TestCheckResourceAttr("data.bytebase_policy.masking_exemption", "masking_exemption_policy.0.exemptions.#", "2"),
+				),
+			},
+			// the expired exemption is removed from the policy but kept in the state
+			{
+				Config: testAccCheckMaskingExemptionResource("2020-01-01T00:00:00Z", "quarterly audit"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bytebase_masking_exemption.ed", "active", "false"),
+					resource.TestCheckResourceAttr("bytebase_masking_exemption.dev", "active", "true"),
+					resource.TestCheckResourceAttr("data.bytebase_policy.masking_exemption", "masking_exemption_policy.0.exemptions.#", "1"),
+					resource.TestCheckResourceAttr("data.bytebase_policy.masking_exemption", "masking_exemption_policy.0.exemptions.0.table", "salary"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMaskingExemption_ExpiredOnCreate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMaskingExemptionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "bytebase_masking_exemption" "expired" {
+	parent           = "projects/project-sample"
+	members          = ["user:ed@bytebase.com"]
+	expire_timestamp = "2020-01-01T00:00:00Z"
+}
+`,
+				ExpectError: regexp.MustCompile("is in the past"),
+			},
+		},
+	})
+}
+
+func testAccCheckMaskingExemptionResource(expireTimestamp, reason string) string {
+	return fmt.Sprintf(`
+resource "bytebase_masking_exemption" "ed" {
+	parent           = "projects/project-sample"
+	members          = ["user:ed@bytebase.com"]
+	database         = "instances/test-sample-instance/databases/employee"
+	reason           = %q
+	expire_timestamp = %q
+}
+
+resource "bytebase_masking_exemption" "dev" {
+	parent   = "projects/project-sample"
+	members  = ["group:dev@bytebase.com"]
+	database = "instances/test-sample-instance/databases/employee"
+	table    = "salary"
+	columns  = ["amount"]
+}
+
+data "bytebase_policy" "masking_exemption" {
+	parent     = "projects/project-sample"
+	type       = "MASKING_EXEMPTION"
+	depends_on = [bytebase_masking_exemption.ed, bytebase_masking_exemption.dev]
+}
+`, reason, expireTimestamp)
+}
+
+func testAccCheckMaskingExemptionDestroy(s *terraform.State) error {
+	c, ok := testAccProvider.Meta().(api.Client)
+	if !ok {
+		return errors.Errorf("cannot get the api client")
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bytebase_masking_exemption" {
+			continue
+		}
+		policyName, err := parseMaskingExemptionID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		policy, err := c.GetPolicy(context.Background(), policyName)
+		if err != nil {
+			if internal.IsNotFoundError(err) {
+				continue
+			}
+			return err
+		}
+		for _, exemption := range policy.GetMaskingExemptionPolicy().GetExemptions() {
+			if getMaskingExemptionID(policyName, exemption) == rs.Primary.ID {
+				return errors.Errorf("masking exemption %s still exists", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestIsSameMaskingExemption(t *testing.T) {
+	exemption := &v1pb.MaskingExemptionPolicy_Exemption{
+		Members:   []string{"user:a@bytebase.com", "user:b@bytebase.com"},
+		Condition: &expr.Expr{Title: "audit", Expression: `resource.instance_id == "prod"`},
+	}
+	tests := []struct {
+		name  string
+		other *v1pb.MaskingExemptionPolicy_Exemption
+		want  bool
+	}{
+		{
+			name: "same members in different order with different reason",
+			other: &v1pb.MaskingExemptionPolicy_Exemption{
+				Members:   []string{"user:b@bytebase.com", "user:a@bytebase.com"},
+				Condition: &expr.Expr{Title: "debug", Expression: `resource.instance_id == "prod"`},
+			},
+			want: true,
+		},
+		{
+			name: "different members",
+			other: &v1pb.MaskingExemptionPolicy_Exemption{
+				Members:   []string{"user:a@bytebase.com"},
+				Condition: &expr.Expr{Expression: `resource.instance_id == "prod"`},
+			},
+			want: false,
+		},
+		{
+			name: "different expression",
+			other: &v1pb.MaskingExemptionPolicy_Exemption{
+				Members:   []string{"user:a@bytebase.com", "user:b@bytebase.com"},
+				Condition: &expr.Expr{Expression: `resource.instance_id == "test"`},
+			},
+			want: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isSameMaskingExemption(exemption, test.other); got != test.want {
+				t.Errorf("isSameMaskingExemption() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestIsMaskingExemptionExpired(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		expireTimestamp string
+		want            bool
+	}{
+		{expireTimestamp: "", want: false},
+		{expireTimestamp: "2025-12-31T23:59:59Z", want: true},
+		{expireTimestamp: "2026-01-01T00:00:00Z", want: true},
+		{expireTimestamp: "2026-01-01T08:00:01+08:00", want: false},
+		{expireTimestamp: "2027-01-01T00:00:00Z", want: false},
+	}
+	for _, test := range tests {
+		if got := isMaskingExemptionExpired(test.expireTimestamp, now); got != test.want {
+			t.Errorf("isMaskingExemptionExpired(%q) = %v, want %v", test.expireTimestamp, got, test.want)
+		}
+	}
+}
+
+func TestAccMaskingExemption_AdoptExisting(t *testing.T) {
+	getConfig := func(adoptExisting bool) string {
+		return fmt.Sprintf(`
+resource "bytebase_masking_exemption" "adopted" {
+	parent         = "projects/project-sample"
+	members        = ["user:adopted@bytebase.com"]
+	database       = "instances/test-sample-instance/databases/employee"
+	reason         = "granted in the UI"
+	adopt_existing = %v
+}
+`, adoptExisting)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMaskingExemptionDestroy,
+		Steps: []resource.TestStep{
+			// the exemption with the same members and condition is granted outside Terraform
+			{
+				PreConfig: func() {
+					c, ok := testAccProvider.Meta().(api.Client)
+					if !ok {
+						t.Fatal("cannot get the api client")
+					}
+					d := schema.TestResourceDataRaw(t, resourceMaskingExemption().Schema, map[string]interface{}{
+						"parent":   "projects/project-sample",
+						"members":  []interface{}{"user:adopted@bytebase.com"},
+						"database": "instances/test-sample-instance/databases/employee",
+					})
+					exemption, err := convertToMaskingExemption(d.Get)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if err := patchMaskingExemptionPolicy(context.Background(), c, getMaskingExemptionPolicyName("projects/project-sample"), func(exemptions []*v1pb.MaskingExemptionPolicy_Exemption) ([]*v1pb.MaskingExemptionPolicy_Exemption, error) {
+						return append(exemptions, exemption), nil
+					}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:      getConfig(false),
+				ExpectError: regexp.MustCompile("already exists in policy .*, set adopt_existing = true to adopt it"),
+			},
+			{
+				Config: getConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists("bytebase_masking_exemption.adopted"),
+					resource.TestCheckResourceAttr("bytebase_masking_exemption.adopted", "active", "true"),
+					resource.TestCheckResourceAttr("bytebase_masking_exemption.adopted", "reason", "granted in the UI"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,294 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/type/expr"
+	"google.golang.org/protobuf/proto"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+// maskingPolicyMutex serializes the read-modify-write on the masking policies in the provider process.
+// It doesn't protect against other Terraform runs, see patchMaskingRulePolicy and patchMaskingExemptionPolicy.
+var maskingPolicyMutex sync.Mutex
+
+func resourceMaskingRule() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage a single rule in the workspace MASKING_RULE policy without touching the rules written by others. Don't use it with the global_masking_policy in the bytebase_policy resource, which manages the whole policy. The policy has no version check, so don't apply the masking rules from concurrent Terraform runs, one run may overwrite the rules written by the other.",
+		CreateContext: resourceMaskingRuleCreate,
+		ReadContext:   resourceMaskingRuleRead,
+		UpdateContext: resourceMaskingRuleUpdate,
+		DeleteContext: resourceMaskingRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+				// Keep the imported rule at its current position.
+				if err := d.Set("priority", -1); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"rule_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The unique rule id in the masking rule policy.",
+			},
+			"title": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The title for the rule.",
+			},
+			"condition": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The CEL condition expression to match the columns, for example resource.environment_id == \"prod\" && resource.column_name == \"phone\".",
+			},
+			"semantic_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The semantic type id to apply for the matched columns.",
+			},
+			"priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validation.IntAtLeast(-1),
+				Description:  "The 0-based position of the rule in the policy. The rules are evaluated in order and the first matched rule applies. The rule is moved to the position on create or when the priority changes, and appended to the end if the priority is -1 or exceeds the rule count. The position is not read back, since other rules can be added around it.",
+			},
+			"policy": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The masking rule policy full name.",
+			},
+		},
+	}
+}
+
+// getMaskingRulePolicyName returns the workspace MASKING_RULE policy name.
+func getMaskingRulePolicyName(client api.Client) string {
+	return fmt.Sprintf("%s/%s%s", client.GetWorkspaceName(), internal.PolicyNamePrefix, v1pb.PolicyType_MASKING_RULE.String())
+}
+
+// getMaskingRuleID returns the masking rule id in {policy}/rules/{rule id} format.
+func getMaskingRuleID(policyName, ruleID string) string {
+	return fmt.Sprintf("%s/rules/%s", policyName, ruleID)
+}
+
+// parseMaskingRuleID parses the masking rule id into the policy name and the rule id.
+func parseMaskingRuleID(id string) (string, string, error) {
+	policyName, ruleID, ok := strings.Cut(id, "/rules/")
+	if !ok || ruleID == "" {
+		return "", "", errors.Errorf("invalid masking rule id %s, expect {policy}/rules/{rule id}", id)
+	}
+	return policyName, ruleID, nil
+}
+
+// insertMaskingRule removes the rule with the same id and inserts the rule at the priority position.
+// The rule keeps its current position if the priority is nil.
+func insertMaskingRule(rules []*v1pb.MaskingRulePolicy_MaskingRule, rule *v1pb.MaskingRulePolicy_MaskingRule, priority *int) []*v1pb.MaskingRulePolicy_MaskingRule {
+	index := slices.IndexFunc(rules, func(r *v1pb.MaskingRulePolicy_MaskingRule) bool {
+		return r.Id == rule.Id
+	})
+	if index >= 0 {
+		if priority == nil {
+			rules[index] = rule
+			return rules
+		}
+		rules = slices.Delete(rules, index, index+1)
+	}
+	position := len(rules)
+	if priority != nil && *priority >= 0 && *priority < len(rules) {
+		position = *priority
+	}
+	return slices.Insert(rules, position, rule)
+}
+
+// getMaskingRules returns the rules in the masking rule policy, the missing policy has no rules.
+func getMaskingRules(ctx context.Context, client api.Client, policyName string) ([]*v1pb.MaskingRulePolicy_MaskingRule, error) {
+	policy, err := client.GetPolicy(ctx, policyName)
+	if err != nil {
+		if internal.IsNotFoundError(err) {
+			return []*v1pb.MaskingRulePolicy_MaskingRule{}, nil
+		}
+		return nil, err
+	}
+	return policy.GetMaskingRulePolicy().GetRules(), nil
+}
+
+func equalMaskingRules(a, b []*v1pb.MaskingRulePolicy_MaskingRule) bool {
+	return slices.EqualFunc(a, b, func(x, y *v1pb.MaskingRulePolicy_MaskingRule) bool {
+		return proto.Equal(x, y)
+	})
+}
+
+// patchMaskingRulePolicy reads the latest masking rule policy, applies the patch and updates the policy.
+// The policy is not updated if the patch makes no change.
+// The policy API has no etag, so the update overwrites the rules written by other Terraform runs or Bytebase users
+// between the read and the update. Don't apply the masking rules of the same workspace from concurrent runs.
+func patchMaskingRulePolicy(ctx context.Context, client api.Client, policyName string, patch func(rules []*v1pb.MaskingRulePolicy_MaskingRule) ([]*v1pb.MaskingRulePolicy_MaskingRule, error)) error {
+	maskingPolicyMutex.Lock()
+	defer maskingPolicyMutex.Unlock()
+
+	current, err := getMaskingRules(ctx, client, policyName)
+	if err != nil {
+		return err
+	}
+	rules := []*v1pb.MaskingRulePolicy_MaskingRule{}
+	for _, rule := range current {
+		rules = append(rules, proto.Clone(rule).(*v1pb.MaskingRulePolicy_MaskingRule))
+	}
+
+	rules, err = patch(rules)
+	if err != nil {
+		return err
+	}
+	if equalMaskingRules(rules, current) {
+		return nil
+	}
+
+	_, err = client.UpsertPolicy(ctx, &v1pb.Policy{
+		Name: policyName,
+		Type: v1pb.PolicyType_MASKING_RULE,
+		Policy: &v1pb.Policy_MaskingRulePolicy{
+			MaskingRulePolicy: &v1pb.MaskingRulePolicy{
+				Rules: rules,
+			},
+		},
+	}, []string{"masking_rule_policy"})
+	return err
+}
+
+func convertToMaskingRule(d *schema.ResourceData) *v1pb.MaskingRulePolicy_MaskingRule {
+	return &v1pb.MaskingRulePolicy_MaskingRule{
+		Id:           d.Get("rule_id").(string),
+		SemanticType: d.Get("semantic_type").(string),
+		Condition: &expr.Expr{
+			Title:      d.Get("title").(string),
+			Expression: d.Get("condition").(string),
+		},
+	}
+}
+
+func resourceMaskingRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	policyName := getMaskingRulePolicyName(c)
+	rule := convertToMaskingRule(d)
+	priority := d.Get("priority").(int)
+
+	if err := patchMaskingRulePolicy(ctx, c, policyName, func(rules []*v1pb.MaskingRulePolicy_MaskingRule) ([]*v1pb.MaskingRulePolicy_MaskingRule, error) {
+		if slices.ContainsFunc(rules, func(r *v1pb.MaskingRulePolicy_MaskingRule) bool {
+			return r.Id == rule.Id
+		}) {
+			return nil, errors.Errorf("masking rule %s already exists in policy %s, import it with the id %s", rule.Id, policyName, getMaskingRuleID(policyName, rule.Id))
+		}
+		return insertMaskingRule(rules, rule, &priority), nil
+	}); err != nil {
+		return diag.Errorf("failed to create masking rule %s with error: %v", rule.Id, err.Error())
+	}
+
+	d.SetId(getMaskingRuleID(policyName, rule.Id))
+	return resourceMaskingRuleRead(ctx, d, m)
+}
+
+func resourceMaskingRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	policyName, ruleID, err := parseMaskingRuleID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policy, err := c.GetPolicy(ctx, policyName)
+	if err != nil {
+		if internal.IsNotFoundError(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Resource %s not found, removing from state", policyName))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	index := slices.IndexFunc(policy.GetMaskingRulePolicy().GetRules(), func(r *v1pb.MaskingRulePolicy_MaskingRule) bool {
+		return r.Id == ruleID
+	})
+	if index < 0 {
+		tflog.Warn(ctx, fmt.Sprintf("masking rule %s not found in policy %s, remove it from the state", ruleID, policyName))
+		d.SetId("")
+		return nil
+	}
+	rule := policy.GetMaskingRulePolicy().GetRules()[index]
+
+	if err := d.Set("policy", policyName); err != nil {
+		return diag.Errorf("cannot set policy for masking rule: %s", err.Error())
+	}
+	if err := d.Set("rule_id", rule.Id); err != nil {
+		return diag.Errorf("cannot set rule_id for masking rule: %s", err.Error())
+	}
+	if err := d.Set("title", rule.GetCondition().GetTitle()); err != nil {
+		return diag.Errorf("cannot set title for masking rule: %s", err.Error())
+	}
+	if err := d.Set("condition", rule.GetCondition().GetExpression()); err != nil {
+		return diag.Errorf("cannot set condition for masking rule: %s", err.Error())
+	}
+	if err := d.Set("semantic_type", rule.SemanticType); err != nil {
+		return diag.Errorf("cannot set semantic_type for masking rule: %s", err.Error())
+	}
+	return nil
+}
+
+func resourceMaskingRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	policyName, _, err := parseMaskingRuleID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rule := convertToMaskingRule(d)
+	var priority *int
+	if d.HasChange("priority") {
+		p := d.Get("priority").(int)
+		priority = &p
+	}
+
+	if err := patchMaskingRulePolicy(ctx, c, policyName, func(rules []*v1pb.MaskingRulePolicy_MaskingRule) ([]*v1pb.MaskingRulePolicy_MaskingRule, error) {
+		return insertMaskingRule(rules, rule, priority), nil
+	}); err != nil {
+		return diag.Errorf("failed to update masking rule %s with error: %v", rule.Id, err.Error())
+	}
+
+	return resourceMaskingRuleRead(ctx, d, m)
+}
+
+func resourceMaskingRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	policyName, ruleID, err := parseMaskingRuleID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := patchMaskingRulePolicy(ctx, c, policyName, func(rules []*v1pb.MaskingRulePolicy_MaskingRule) ([]*v1pb.MaskingRulePolicy_MaskingRule, error) {
+		return slices.DeleteFunc(rules, func(r *v1pb.MaskingRulePolicy_MaskingRule) bool {
+			return r.Id == ruleID
+		}), nil
+	}); err != nil {
+		return diag.Errorf("failed to delete masking rule %s with error: %v", ruleID, err.Error())
+	}
+
+	d.SetId("")
+	return nil
+}
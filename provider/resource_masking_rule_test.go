@@ -0,0 +1,253 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func TestAccMaskingRule(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMaskingRuleDestroy,
+		Steps: []resource.TestStep{
+			// the email rule is inserted before the phone rule
+			{
+				Config: testAccCheckMaskingRuleResource(`resource.column_name == "phone"`, -1),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists("bytebase_masking_rule.phone"),
+					resource.TestCheckResourceAttr("bytebase_masking_rule.phone", "id", fmt.Sprintf("workspaces/%s/policies/MASKING_RULE/rules/phone", internal.MockWorkspaceID)),
+					resource.TestCheckResourceAttr("bytebase_masking_rule.phone", "semantic_type", "default"),
+					resource.TestCheckResourceAttr("data.bytebase_policy.masking_rule", "global_masking_policy.0.rules.#", "2"),
+					resource.TestCheckResourceAttr("data.bytebase_policy.masking_rule", "global_masking_policy.0.rules.0.id", "email"),
+					resource.TestCheckResourceAttr("data.bytebase_policy.masking_rule", "global_masking_policy.0.rules.1.id", "phone"),
+				),
+			},
+			// move the phone rule to the top and update the condition
+			{
+				Config: testAccCheckMaskingRuleResource(`resource.column_name == "mobile"`, 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bytebase_masking_rule.phone", "condition", `resource.column_name == "mobile"`),
+					resource.TestCheckResourceAttr("data.bytebase_policy.masking_rule", "global_masking_policy.0.rules.#", "2"),
+					resource.TestCheckResourceAttr("data.bytebase_policy.masking_rule", "global_masking_policy.0.rules.0.id", "phone"),
+					resource.TestCheckResourceAttr("data.bytebase_policy.masking_rule", "global_masking_policy.0.rules.0.condition", `resource.column_name == "mobile"`),
+					resource.TestCheckResourceAttr("data.bytebase_policy.masking_rule", "global_masking_policy.0.rules.1.id", "email"),
+				),
+			},
+			{
+				ResourceName:            "bytebase_masking_rule.phone",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"priority"},
+			},
+		},
+	})
+}
+
+func testAccCheckMaskingRuleResource(phoneCondition string, phonePriority int) string {
+	return fmt.Sprintf(`
+resource "bytebase_masking_rule" "phone" {
+	rule_id       = "phone"
+	title         = "phone"
+	condition     = %q
+	semantic_type = "default"
+	priority      = %d
+}
+
+resource "bytebase_masking_rule" "email" {
+	rule_id       = "email"
+	condition     = "resource.column_name == \"email\""
+	semantic_type = "default"
+	priority      = 0
+	depends_on    = [bytebase_masking_rule.phone]
+}
+
+data "bytebase_policy" "masking_rule" {
+	type       = "MASKING_RULE"
+	depends_on = [bytebase_masking_rule.email]
+}
+`, phoneCondition, phonePriority)
+}
+
+func testAccCheckMaskingRuleDestroy(s *terraform.State) error {
+	c, ok := testAccProvider.Meta().(api.Client)
+	if !ok {
+		return errors.Errorf("cannot get the api client")
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bytebase_masking_rule" {
+			continue
+		}
+		policyName, ruleID, err := parseMaskingRuleID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		policy, err := c.GetPolicy(context.Background(), policyName)
+		if err != nil {
+			if internal.IsNotFoundError(err) {
+				continue
+			}
+			return err
+		}
+		for _, rule := range policy.GetMaskingRulePolicy().GetRules() {
+			if rule.Id == ruleID {
+				return errors.Errorf("masking rule %s still exists", ruleID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestInsertMaskingRule(t *testing.T) {
+	getRules := func(ids ...string) []*v1pb.MaskingRulePolicy_MaskingRule {
+		rules := []*v1pb.MaskingRulePolicy_MaskingRule{}
+		for _, id := range ids {
+			rules = append(rules, &v1pb.MaskingRulePolicy_MaskingRule{Id: id})
+		}
+		return rules
+	}
+	getIDs := func(rules []*v1pb.MaskingRulePolicy_MaskingRule) []string {
+		ids := []string{}
+		for _, rule := range rules {
+			ids = append(ids, rule.Id)
+		}
+		return ids
+	}
+	priority := func(p int) *int {
+		return &p
+	}
+
+	tests := []struct {
+		name     string
+		rules    []string
+		rule     string
+		priority *int
+		want     []string
+	}{
+		{
+			name:     "append the new rule",
+			rules:    []string{"a", "b"},
+			rule:     "c",
+			priority: priority(-1),
+			want:     []string{"a", "b", "c"},
+		},
+		{
+			name:     "insert the new rule at the priority",
+			rules:    []string{"a", "b"},
+			rule:     "c",
+			priority: priority(1),
+			want:     []string{"a", "c", "b"},
+		},
+		{
+			name:     "append the rule if the priority exceeds the rule count",
+			rules:    []string{"a", "b"},
+			rule:     "c",
+			priority: priority(10),
+			want:     []string{"a", "b", "c"},
+		},
+		{
+			name:     "move the existing rule",
+			rules:    []string{"a", "b", "c"},
+			rule:     "c",
+			priority: priority(0),
+			want:     []string{"c", "a", "b"},
+		},
+		{
+			name:  "keep the existing rule position without the priority",
+			rules: []string{"a", "b", "c"},
+			rule:  "b",
+			want:  []string{"a", "b", "c"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := getIDs(insertMaskingRule(getRules(test.rules...), &v1pb.MaskingRulePolicy_MaskingRule{Id: test.rule}, test.priority))
+			if !slices.Equal(got, test.want) {
+				t.Fatalf("insertMaskingRule() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseMaskingRuleID(t *testing.T) {
+	policyName, ruleID, err := parseMaskingRuleID(getMaskingRuleID("workspaces/w/policies/MASKING_RULE", "phone"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policyName != "workspaces/w/policies/MASKING_RULE" || ruleID != "phone" {
+		t.Errorf("parseMaskingRuleID() = %q, %q", policyName, ruleID)
+	}
+	if _, _, err := parseMaskingRuleID("workspaces/w/policies/MASKING_RULE"); err == nil {
+		t.Errorf("parseMaskingRuleID() expect error for the policy name")
+	}
+}
+
+// fakeMaskingRuleClient keeps the masking rule policy in memory and counts the updates.
+type fakeMaskingRuleClient struct {
+	api.Client
+	policy  *v1pb.Policy
+	upserts int
+}
+
+func (c *fakeMaskingRuleClient) GetPolicy(_ context.Context, _ string) (*v1pb.Policy, error) {
+	return proto.Clone(c.policy).(*v1pb.Policy), nil
+}
+
+func (c *fakeMaskingRuleClient) UpsertPolicy(_ context.Context, patch *v1pb.Policy, _ []string) (*v1pb.Policy, error) {
+	c.upserts++
+	c.policy = proto.Clone(patch).(*v1pb.Policy)
+	return patch, nil
+}
+
+func TestPatchMaskingRulePolicy(t *testing.T) {
+	client := &fakeMaskingRuleClient{
+		policy: &v1pb.Policy{
+			Name: "workspaces/w/policies/masking_rule",
+			Policy: &v1pb.Policy_MaskingRulePolicy{
+				MaskingRulePolicy: &v1pb.MaskingRulePolicy{
+					Rules: []*v1pb.MaskingRulePolicy_MaskingRule{{Id: "others"}},
+				},
+			},
+		},
+	}
+	addPhone := func(rules []*v1pb.MaskingRulePolicy_MaskingRule) ([]*v1pb.MaskingRulePolicy_MaskingRule, error) {
+		if slices.ContainsFunc(rules, func(rule *v1pb.MaskingRulePolicy_MaskingRule) bool { return rule.Id == "phone" }) {
+			return rules, nil
+		}
+		return append(rules, &v1pb.MaskingRulePolicy_MaskingRule{Id: "phone"}), nil
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := patchMaskingRulePolicy(context.Background(), client, client.policy.Name, addPhone); err != nil {
+			t.Fatalf("patchMaskingRulePolicy returned error: %v", err)
+		}
+	}
+
+	ids := []string{}
+	for _, rule := range client.policy.GetMaskingRulePolicy().GetRules() {
+		ids = append(ids, rule.Id)
+	}
+	if want := []string{"others", "phone"}; !slices.Equal(ids, want) {
+		t.Errorf("rules = %v, want %v", ids, want)
+	}
+	// The second patch makes no change, so the policy is not updated.
+	if client.upserts != 1 {
+		t.Errorf("upserts = %d, want 1", client.upserts)
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/pkg/errors"
 	"google.golang.org/genproto/googleapis/type/expr"
 
@@ -63,15 +62,10 @@ func resourcePolicy() *schema.Resource {
 				Description: "The policy parent name for the policy, support workspaces/{workspace id}, projects/{resource id}, environments/{resource id}, instances/{resource id}, or instances/{resource id}/databases/{database name}. Defaults to the workspace if not specified.",
 			},
 			"type": {
-				Type:     schema.TypeString,
-				Required: true,
-				ValidateFunc: validation.StringInSlice([]string{
-					v1pb.PolicyType_MASKING_EXEMPTION.String(),
-					v1pb.PolicyType_MASKING_RULE.String(),
-					v1pb.PolicyType_ROLLOUT_POLICY.String(),
-					v1pb.PolicyType_DATA_QUERY.String(),
-				}, false),
-				Description: "The policy type.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: getPolicyTypeValidation(),
+				Description:  "The policy type.",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -94,6 +88,7 @@ func resourcePolicy() *schema.Resource {
 			"global_masking_policy":    getGlobalMaskingPolicySchema(false),
 			"rollout_policy":           getRolloutPolicySchema(false),
 			"query_data_policy":        getDataQueryPolicySchema(false),
+			"tag_policy":               getTagPolicySchema(false),
 		},
 	}
 }
@@ -194,6 +189,18 @@ func resourcePolicyCreate(ctx context.Context, d *schema.ResourceData, m interfa
 			QueryDataPolicy: queryDataPolicy,
 		}
 		updateMasks = append(updateMasks, "query_data_policy")
+	case v1pb.PolicyType_TAG:
+		if !strings.HasPrefix(parent, internal.ProjectNamePrefix) && !strings.HasPrefix(parent, internal.EnvironmentNamePrefix) {
+			return diag.Errorf("policy %v only support project or environment resource", policyName)
+		}
+		tagPolicy, err := convertToTagPolicy(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		patch.Policy = &v1pb.Policy_TagPolicy{
+			TagPolicy: tagPolicy,
+		}
+		updateMasks = append(updateMasks, "tag_policy")
 	default:
 		return diag.Errorf("unsupport policy type: %v", policyName)
 	}
@@ -295,6 +302,16 @@ func resourcePolicyUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 			QueryDataPolicy: queryDataPolicy,
 		}
 	}
+	if d.HasChange("tag_policy") {
+		updateMasks = append(updateMasks, "tag_policy")
+		tagPolicy, err := convertToTagPolicy(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		patch.Policy = &v1pb.Policy_TagPolicy{
+			TagPolicy: tagPolicy,
+		}
+	}
 
 	var diags diag.Diagnostics
 	if len(updateMasks) > 0 {
@@ -481,3 +498,23 @@ func convertToQueryDataPolicy(d *schema.ResourceData) (*v1pb.QueryDataPolicy, er
 		AllowAdminDataSource: raw["allow_admin_data_source"].(bool),
 	}, nil
 }
+
+func convertToTagPolicy(d *schema.ResourceData) (*v1pb.TagPolicy, error) {
+	rawList, ok := d.Get("tag_policy").([]interface{})
+	if !ok || len(rawList) != 1 || rawList[0] == nil {
+		return nil, errors.Errorf("invalid tag_policy")
+	}
+
+	raw := rawList[0].(map[string]interface{})
+	policy := &v1pb.TagPolicy{
+		Tags: map[string]string{},
+	}
+	rawTags, ok := raw["tags"].(map[string]interface{})
+	if !ok {
+		return policy, nil
+	}
+	for key, value := range rawTags {
+		policy.Tags[key] = value.(string)
+	}
+	return policy, nil
+}
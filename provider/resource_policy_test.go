@@ -3,6 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"slices"
 	"testing"
 
 	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
@@ -117,6 +119,182 @@ func getQueryDataPolicy(disableExport bool, maxResultRows int) string {
 	`, disableExport, maxResultRows)
 }
 
+func TestAccPolicy_Rollout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPolicyResource(
+					"rollout_policy",
+					"environments/test",
+					getRolloutPolicy(false, "roles/projectOwner"),
+					v1pb.PolicyType_ROLLOUT_POLICY,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists("bytebase_policy.rollout_policy"),
+					resource.TestCheckResourceAttr("bytebase_policy.rollout_policy", "type", v1pb.PolicyType_ROLLOUT_POLICY.String()),
+					resource.TestCheckResourceAttr("bytebase_policy.rollout_policy", "rollout_policy.#", "1"),
+					resource.TestCheckResourceAttr("bytebase_policy.rollout_policy", "rollout_policy.0.automatic", "false"),
+					resource.TestCheckResourceAttr("bytebase_policy.rollout_policy", "rollout_policy.0.roles.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckPolicyResource(
+					"rollout_policy",
+					"environments/test",
+					getRolloutPolicy(true, "roles/projectOwner"),
+					v1pb.PolicyType_ROLLOUT_POLICY,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bytebase_policy.rollout_policy", "rollout_policy.0.automatic", "true"),
+				),
+			},
+		},
+	})
+}
+
+func getRolloutPolicy(automatic bool, role string) string {
+	return fmt.Sprintf(`
+	rollout_policy {
+		automatic = %t
+		roles     = ["%s"]
+	}
+	`, automatic, role)
+}
+
+func TestAccPolicy_Tag(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPolicyResource(
+					"tag_policy",
+					"projects/project-sample",
+					getTagPolicy("reviewConfigs/sql-review"),
+					v1pb.PolicyType_TAG,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists("bytebase_policy.tag_policy"),
+					resource.TestCheckResourceAttr("bytebase_policy.tag_policy", "type", v1pb.PolicyType_TAG.String()),
+					resource.TestCheckResourceAttr("bytebase_policy.tag_policy", "tag_policy.#", "1"),
+					resource.TestCheckResourceAttr("bytebase_policy.tag_policy", "tag_policy.0.tags.%", "1"),
+					resource.TestCheckResourceAttr("bytebase_policy.tag_policy", "tag_policy.0.tags.bb.tag.review_config", "reviewConfigs/sql-review"),
+				),
+			},
+			{
+				Config: testAccCheckPolicyResource(
+					"tag_policy",
+					"projects/project-sample",
+					getTagPolicy("reviewConfigs/strict-review"),
+					v1pb.PolicyType_TAG,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bytebase_policy.tag_policy", "tag_policy.0.tags.bb.tag.review_config", "reviewConfigs/strict-review"),
+				),
+			},
+			{
+				Config: testAccCheckPolicyResource(
+					"instance_tag_policy",
+					"instances/test-sample-instance",
+					getTagPolicy("reviewConfigs/sql-review"),
+					v1pb.PolicyType_TAG,
+				),
+				ExpectError: regexp.MustCompile("only support project or environment resource"),
+			},
+		},
+	})
+}
+
+func getTagPolicy(reviewConfig string) string {
+	return fmt.Sprintf(`
+	tag_policy {
+		tags = {
+			"bb.tag.review_config" = "%s"
+		}
+	}
+	`, reviewConfig)
+}
+
+func TestFlattenPolicyPayload(t *testing.T) {
+	policies := []*v1pb.Policy{
+		{
+			Name: "projects/p/policies/MASKING_EXEMPTION",
+			Policy: &v1pb.Policy_MaskingExemptionPolicy{
+				MaskingExemptionPolicy: &v1pb.MaskingExemptionPolicy{},
+			},
+		},
+		{
+			Name: "workspaces/w/policies/MASKING_RULE",
+			Policy: &v1pb.Policy_MaskingRulePolicy{
+				MaskingRulePolicy: &v1pb.MaskingRulePolicy{},
+			},
+		},
+		{
+			Name: "environments/e/policies/ROLLOUT_POLICY",
+			Policy: &v1pb.Policy_RolloutPolicy{
+				RolloutPolicy: &v1pb.RolloutPolicy{Automatic: true},
+			},
+		},
+		{
+			Name: "projects/p/policies/DATA_QUERY",
+			Policy: &v1pb.Policy_QueryDataPolicy{
+				QueryDataPolicy: &v1pb.QueryDataPolicy{MaximumResultRows: 100},
+			},
+		},
+		{
+			Name: "projects/p/policies/TAG",
+			Policy: &v1pb.Policy_TagPolicy{
+				TagPolicy: &v1pb.TagPolicy{Tags: map[string]string{"bb.tag.review_config": "reviewConfigs/r"}},
+			},
+		},
+	}
+
+	flattened := map[v1pb.PolicyType]bool{}
+	for _, policy := range policies {
+		key, payload, diags := flattenPolicyPayload(policy)
+		if diags.HasError() {
+			t.Fatalf("flattenPolicyPayload(%s) error = %v", policy.Name, diags)
+		}
+		if _, ok := resourcePolicy().Schema[key]; !ok {
+			t.Errorf("flattenPolicyPayload(%s) key %q is not in the schema", policy.Name, key)
+		}
+		if list, ok := payload.([]interface{}); !ok || len(list) != 1 {
+			t.Errorf("flattenPolicyPayload(%s) payload = %v, want one block", policy.Name, payload)
+		}
+		_, policyType, err := internal.GetPolicyParentAndType(policy.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		flattened[policyType] = true
+	}
+
+	// Every policy type exposed by the API should be modeled.
+	for value, name := range v1pb.PolicyType_name {
+		policyType := v1pb.PolicyType(value)
+		if policyType == v1pb.PolicyType_POLICY_TYPE_UNSPECIFIED {
+			continue
+		}
+		if !slices.Contains(supportedPolicyTypes, policyType) {
+			t.Errorf("policy type %s is not supported", name)
+		}
+		if !flattened[policyType] {
+			t.Errorf("policy type %s is not flattened", name)
+		}
+	}
+
+	if _, _, diags := flattenPolicyPayload(&v1pb.Policy{Name: "projects/p/policies/TAG"}); !diags.HasError() {
+		t.Errorf("flattenPolicyPayload() expect error for the empty payload")
+	}
+}
+
 func testAccCheckPolicyDestroy(s *terraform.State) error {
 	c, ok := testAccProvider.Meta().(api.Client)
 	if !ok {
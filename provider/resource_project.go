@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/pkg/errors"
@@ -34,7 +35,10 @@ func resourceProjct() *schema.Resource {
 		ReadWithoutTimeout:   resourceProjectRead,
 		UpdateWithoutTimeout: resourceProjectUpdate,
 		DeleteContext:        resourceProjectDelete,
-		CustomizeDiff:        validateProjectWebhooks,
+		CustomizeDiff: customdiff.All(
+			validateProjectWebhooks,
+			validateAdoptExisting("project", "resource_id", findExistingProject),
+		),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -169,8 +173,31 @@ func resourceProjectSchema() map[string]*schema.Schema {
 				Type: schema.TypeString,
 			},
 		},
-		"databases": getDatabasesSchema(false),
-		"webhooks":  getWebhooksSchema(false, true),
+		"databases": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Computed:    true,
+			Description: "The databases full name in the resource. Leave it unset if the databases are assigned by bytebase_database or bytebase_project_databases resources.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"webhooks": getWebhooksSchema(false, true),
+		"purge_on_destroy": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Permanently delete the project on destroy instead of the soft delete, so a new project can be created with the same resource_id. The purged project cannot be restored.",
+		},
+		"force": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Move the databases in the project to the default project before the delete. Otherwise the delete fails if the project still has databases.",
+		},
+		"deletion_protection": getDeletionProtectionSchema("project", true),
+		"adopt_existing":      getAdoptExistingSchema("project"),
+		"adoption":            getAdoptionSchema("project"),
 	}
 }
 
@@ -203,13 +230,17 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interf
 		AllowRequestRole:           d.Get("allow_request_role").(bool),
 		AllowJustInTimeAccess:      d.Get("allow_just_in_time_access").(bool),
 		IssueLabels:                issueLabels,
-		Labels:                     convertToStringMap(d.Get("labels").(map[string]interface{})),
 	}
 
 	existedProject, err := c.GetProject(ctx, projectName)
-	if err != nil {
-		tflog.Debug(ctx, fmt.Sprintf("get project %s failed with error: %v", projectName, err))
+	if err != nil && !internal.IsNotFoundError(err) {
+		return diag.Errorf("get project %s failed with error: %v", projectName, err)
+	}
+	if diags := checkAdoptExisting(d, "project", projectName, existedProject != nil && err == nil, existedProject.GetState()); diags.HasError() {
+		return diags
 	}
+	labels, labelsChanged := getLabelsPatch(c.GetLabelConfig(), d, existedProject.GetLabels())
+	project.Labels = labels
 
 	rawConfig := d.GetRawConfig()
 	updateMasks := []string{}
@@ -255,7 +286,7 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interf
 	if config := rawConfig.GetAttr("issue_labels"); !config.IsNull() {
 		updateMasks = append(updateMasks, "issue_labels")
 	}
-	if config := rawConfig.GetAttr("labels"); !config.IsNull() {
+	if labelsChanged {
 		updateMasks = append(updateMasks, "labels")
 	}
 
@@ -407,7 +438,8 @@ func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	if d.HasChange("issue_labels") {
 		paths = append(paths, "issue_labels")
 	}
-	if d.HasChange("labels") {
+	labels, ok := getLabelsPatch(c.GetLabelConfig(), d, existedProject.Labels)
+	if ok {
 		paths = append(paths, "labels")
 	}
 
@@ -436,7 +468,7 @@ func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interf
 			AllowRequestRole:           d.Get("allow_request_role").(bool),
 			AllowJustInTimeAccess:      d.Get("allow_just_in_time_access").(bool),
 			IssueLabels:                issueLabels,
-			Labels:                     convertToStringMap(d.Get("labels").(map[string]interface{})),
+			Labels:                     labels,
 		}, paths); err != nil {
 			diags = append(diags, diag.FromErr(err)...)
 			return diags
@@ -473,7 +505,14 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return diag.FromErr(err)
 	}
 
+	managed := d.Get("labels").(map[string]interface{})
 	resp := setProject(ctx, c, d, project, true)
+	if resp.HasError() {
+		return resp
+	}
+	if err := d.Set("labels", flattenLabels(c.GetLabelConfig(), project.Labels, managed)); err != nil {
+		return diag.Errorf("cannot set labels for project: %s", err.Error())
+	}
 	tflog.Debug(ctx, "[read project] read project finished", map[string]interface{}{
 		"project": project.Name,
 	})
@@ -481,9 +520,40 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interfac
 	return resp
 }
 
+// findExistingProject returns the name and state of the existing project by the resource id.
+func findExistingProject(ctx context.Context, c api.Client, projectID string) (string, v1pb.State, error) {
+	project, err := c.GetProject(ctx, fmt.Sprintf("%s%s", internal.ProjectNamePrefix, projectID))
+	if err != nil {
+		return "", v1pb.State_STATE_UNSPECIFIED, err
+	}
+	return project.Name, project.State, nil
+}
+
 func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if diags := checkDeletionProtection(d, "project"); diags.HasError() {
+		return diags
+	}
 	c := m.(api.Client)
-	return internal.ResourceDelete(ctx, d, c.DeleteProject)
+	projectName := d.Id()
+
+	if d.Get("force").(bool) {
+		databases, err := c.ListDatabase(ctx, projectName, &api.DatabaseFilter{}, true)
+		if err != nil && !internal.IsNotFoundError(err) {
+			return diag.Errorf("failed to list database with error: %v", err.Error())
+		}
+		databaseNames := []string{}
+		for _, database := range databases {
+			databaseNames = append(databaseNames, database.Name)
+		}
+		if _, diags := batchAssignDatabases(ctx, c, databaseNames, c.GetDefaultProjectName(), batchSize); diags.HasError() {
+			return diags
+		}
+	}
+
+	purge := d.Get("purge_on_destroy").(bool)
+	return internal.ResourceDelete(ctx, d, func(ctx context.Context, name string) error {
+		return c.DeleteProject(ctx, name, purge)
+	})
 }
 
 const batchSize = 100
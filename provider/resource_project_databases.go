@@ -0,0 +1,410 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func resourceProjectDatabases() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Assign databases to the project in batches. Only the databases managed by this resource are unassigned on update or destroy, other databases in the project are left alone.",
+		CreateContext: resourceProjectDatabasesUpsert,
+		ReadContext:   resourceProjectDatabasesRead,
+		UpdateContext: resourceProjectDatabasesUpsert,
+		DeleteContext: resourceProjectDatabasesDelete,
+		CustomizeDiff: resolveProjectDatabasesDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateDiagFunc: internal.ResourceNameValidation(
+					// project name format
+					fmt.Sprintf("^%s%s$", internal.ProjectNamePrefix, internal.ResourceIDPattern),
+				),
+				Description: "The project full name in projects/{project} format.",
+			},
+			"databases": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"databases", "selector"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateDiagFunc: internal.ResourceNameValidation(
+						// database name format
+						fmt.Sprintf(`^%s%s/%s\S+$`, internal.InstanceNamePrefix, internal.ResourceIDPattern, internal.DatabaseIDPrefix),
+					),
+				},
+				Description: "The database full names in instances/{instance}/databases/{database} format to assign to the project.",
+			},
+			"selector": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"databases", "selector"},
+				Description:  "Select the databases to assign to the project. The selector is resolved on every plan, so newly matched databases show in the plan.",
+				Elem: &schema.Resource{
+					Schema: getDatabaseSelectorSchema(),
+				},
+			},
+			"batch_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      batchSize,
+				ValidateFunc: validation.IntBetween(1, 1000),
+				Description:  "The count of databases to assign in a single batch request.",
+			},
+			"resolved_databases": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The database full names assigned to the project by this resource.",
+			},
+		},
+	}
+}
+
+func getDatabaseSelectorSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"instances": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+				ValidateDiagFunc: internal.ResourceNameValidation(
+					fmt.Sprintf("^%s%s$", internal.InstanceNamePrefix, internal.ResourceIDPattern),
+				),
+			},
+			Description: "Select databases in the instances full name. Select databases in all instances if not set.",
+		},
+		"environment": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ValidateDiagFunc: internal.ResourceNameValidation(
+				fmt.Sprintf("^%s%s$", internal.EnvironmentNamePrefix, internal.ResourceIDPattern),
+			),
+			Description: "Select databases by the environment full name.",
+		},
+		"engines": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: internal.EngineValidation,
+			},
+			Description: "Select databases by engines.",
+		},
+		"query": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Select databases by name with wildcard.",
+		},
+		"labels": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Select databases matching all the labels.",
+		},
+		"filter": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: `The raw CEL filter for the list databases API joined with other conditions, for example name.matches("tenant_").`,
+		},
+	}
+}
+
+// resolveDatabaseSelector lists the database full names matching the selector.
+func resolveDatabaseSelector(ctx context.Context, client api.Client, raw map[string]interface{}) ([]string, error) {
+	databases, err := listDatabasesBySelector(ctx, client, raw)
+	if err != nil {
+		return nil, err
+	}
+	databaseNames := []string{}
+	for _, database := range databases {
+		databaseNames = append(databaseNames, database.Name)
+	}
+	return databaseNames, nil
+}
+
+// listDatabasesBySelector lists the active databases matching the selector, sorted by name.
+func listDatabasesBySelector(ctx context.Context, client api.Client, raw map[string]interface{}) ([]*v1pb.Database, error) {
+	filter := &api.DatabaseFilter{
+		Environment: raw["environment"].(string),
+		Query:       raw["query"].(string),
+		Expression:  raw["filter"].(string),
+	}
+	if rawEngines, ok := raw["engines"].(*schema.Set); ok {
+		for _, engine := range rawEngines.List() {
+			if engineValue, ok := v1pb.Engine_value[engine.(string)]; ok {
+				filter.Engines = append(filter.Engines, v1pb.Engine(engineValue))
+			}
+		}
+	}
+	if rawLabels, ok := raw["labels"].(map[string]interface{}); ok {
+		for key, val := range convertToStringMap(rawLabels) {
+			filter.Labels = append(filter.Labels, &api.Label{
+				Key:   key,
+				Value: val,
+			})
+		}
+	}
+
+	parents := []string{client.GetWorkspaceName()}
+	if rawInstances, ok := raw["instances"].(*schema.Set); ok && rawInstances.Len() > 0 {
+		parents = []string{}
+		for _, instance := range rawInstances.List() {
+			parents = append(parents, instance.(string))
+		}
+	}
+
+	databaseMap := map[string]*v1pb.Database{}
+	for _, parent := range parents {
+		databases, err := client.ListDatabase(ctx, parent, filter, true)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list databases in %s", parent)
+		}
+		for _, database := range databases {
+			if database.State == v1pb.State_DELETED {
+				continue
+			}
+			databaseMap[database.Name] = database
+		}
+	}
+
+	databases := []*v1pb.Database{}
+	for _, database := range databaseMap {
+		databases = append(databases, database)
+	}
+	slices.SortFunc(databases, func(a, b *v1pb.Database) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return databases, nil
+}
+
+// getSelectorConfig returns the raw selector config, or nil if the selector is not set.
+func getSelectorConfig(rawList []interface{}) map[string]interface{} {
+	if len(rawList) != 1 || rawList[0] == nil {
+		return nil
+	}
+	return rawList[0].(map[string]interface{})
+}
+
+func resolveProjectDatabasesDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if !diff.NewValueKnown("selector") {
+		return diff.SetNewComputed("resolved_databases")
+	}
+	selector := getSelectorConfig(diff.Get("selector").([]interface{}))
+	if selector == nil {
+		if diff.HasChange("databases") {
+			return diff.SetNewComputed("resolved_databases")
+		}
+		return nil
+	}
+
+	databaseNames, err := resolveDatabaseSelector(ctx, m.(api.Client), selector)
+	if err != nil {
+		return err
+	}
+	resolved := convertToStringSet(databaseNames)
+	if old, ok := diff.Get("resolved_databases").(*schema.Set); ok && old.Equal(resolved) {
+		return nil
+	}
+	return diff.SetNew("resolved_databases", resolved)
+}
+
+func resourceProjectDatabasesUpsert(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	projectName := d.Get("project").(string)
+
+	desired := []string{}
+	if selector := getSelectorConfig(d.Get("selector").([]interface{})); selector != nil {
+		databaseNames, err := resolveDatabaseSelector(ctx, c, selector)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		desired = databaseNames
+	} else {
+		for _, raw := range d.Get("databases").(*schema.Set).List() {
+			desired = append(desired, raw.(string))
+		}
+	}
+
+	// Only unassign the databases previously assigned by this resource.
+	oldResolved, _ := d.GetChange("resolved_databases")
+	unassigned := []string{}
+	for _, raw := range oldResolved.(*schema.Set).List() {
+		if databaseName := raw.(string); !slices.Contains(desired, databaseName) {
+			unassigned = append(unassigned, databaseName)
+		}
+	}
+
+	size := d.Get("batch_size").(int)
+	assigned, diags := batchAssignDatabases(ctx, c, desired, projectName, size)
+	_, unassignDiags := batchAssignDatabases(ctx, c, unassigned, c.GetDefaultProjectName(), size)
+	diags = append(diags, unassignDiags...)
+
+	d.SetId(projectName)
+	if err := d.Set("resolved_databases", assigned); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if getSelectorConfig(d.Get("selector").([]interface{})) == nil {
+		if err := d.Set("databases", assigned); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceProjectDatabasesRead(ctx, d, m)...)
+}
+
+// batchAssignDatabases assigns the databases to the project in batches.
+// If a batch fails, the databases in the batch are assigned one by one to report the failures per database.
+// It returns the databases assigned successfully.
+func batchAssignDatabases(ctx context.Context, client api.Client, databaseNames []string, projectName string, size int) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	assigned := []string{}
+
+	for i := 0; i < len(databaseNames); i += size {
+		end := min(i+size, len(databaseNames))
+		batch := databaseNames[i:end]
+		startTime := time.Now()
+
+		requests := []*v1pb.UpdateDatabaseRequest{}
+		for _, databaseName := range batch {
+			requests = append(requests, &v1pb.UpdateDatabaseRequest{
+				Database: &v1pb.Database{
+					Name:    databaseName,
+					Project: projectName,
+				},
+				UpdateMask: &fieldmaskpb.FieldMask{
+					Paths: []string{"project"},
+				},
+			})
+		}
+
+		_, err := client.BatchUpdateDatabases(ctx, &v1pb.BatchUpdateDatabasesRequest{
+			Requests: requests,
+			Parent:   "instances/-",
+		})
+		if err == nil {
+			assigned = append(assigned, batch...)
+			tflog.Debug(ctx, "[assign databases]", map[string]interface{}{
+				"count":   len(batch),
+				"project": projectName,
+				"ms":      time.Since(startTime).Milliseconds(),
+			})
+			continue
+		}
+		tflog.Debug(ctx, fmt.Sprintf("batch assign databases to project %s failed with error: %v, fallback to assign one by one", projectName, err))
+
+		for _, databaseName := range batch {
+			if _, err := client.UpdateDatabase(ctx, &v1pb.Database{
+				Name:    databaseName,
+				Project: projectName,
+			}, []string{"project"}); err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Failed to assign database",
+					Detail:   fmt.Sprintf("Assign database %s to project %s failed, error: %v", databaseName, projectName, err),
+				})
+				continue
+			}
+			assigned = append(assigned, databaseName)
+		}
+	}
+
+	return assigned, diags
+}
+
+func resourceProjectDatabasesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+	projectName := d.Id()
+
+	databases, err := c.ListDatabase(ctx, projectName, &api.DatabaseFilter{}, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	inProject := []string{}
+	for _, database := range databases {
+		inProject = append(inProject, database.Name)
+	}
+
+	resolved := d.Get("resolved_databases").(*schema.Set).List()
+	isImport := len(resolved) == 0 && d.Get("databases").(*schema.Set).Len() == 0 && getSelectorConfig(d.Get("selector").([]interface{})) == nil
+	// Keep the databases still in the project, so the databases moved outside are assigned again in the next apply.
+	assigned := []string{}
+	if isImport {
+		assigned = inProject
+	} else {
+		for _, raw := range resolved {
+			if databaseName := raw.(string); slices.Contains(inProject, databaseName) {
+				assigned = append(assigned, databaseName)
+			}
+		}
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.Errorf("cannot set project: %s", err.Error())
+	}
+	if err := d.Set("resolved_databases", assigned); err != nil {
+		return diag.Errorf("cannot set resolved_databases: %s", err.Error())
+	}
+	if getSelectorConfig(d.Get("selector").([]interface{})) == nil {
+		if err := d.Set("databases", assigned); err != nil {
+			return diag.Errorf("cannot set databases: %s", err.Error())
+		}
+	}
+	return nil
+}
+
+func resourceProjectDatabasesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(api.Client)
+
+	unassigned := []string{}
+	for _, raw := range d.Get("resolved_databases").(*schema.Set).List() {
+		unassigned = append(unassigned, raw.(string))
+	}
+	if _, diags := batchAssignDatabases(ctx, c, unassigned, c.GetDefaultProjectName(), d.Get("batch_size").(int)); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func convertToStringSet(list []string) *schema.Set {
+	set := schema.NewSet(schema.HashString, nil)
+	for _, v := range list {
+		set.Add(v)
+	}
+	return set
+}
+
+func convertToStringList(set *schema.Set) []string {
+	list := []string{}
+	for _, v := range set.List() {
+		list = append(list, v.(string))
+	}
+	return list
+}
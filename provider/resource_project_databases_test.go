@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+	"github.com/bytebase/terraform-provider-bytebase/provider/internal"
+)
+
+func TestAccProjectDatabases(t *testing.T) {
+	identifier := "project_databases"
+	resourceName := fmt.Sprintf("bytebase_project_databases.%s", identifier)
+	instanceID := "project-databases-instance"
+
+	base := fmt.Sprintf(`
+%s
+
+%s
+`,
+		testAccCheckInstanceResource("project_databases_instance", instanceID, "project databases instance", "POSTGRES", "environments/test"),
+		testAccCheckProjectResource("project_databases_project", "project-databases", "project databases"),
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckProjectDestroy,
+		Steps: []resource.TestStep{
+			// assign databases by names
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "bytebase_project_databases" "%s" {
+	project   = bytebase_project.project_databases_project.name
+	databases = [
+		"${bytebase_instance.project_databases_instance.name}/databases/default",
+		"${bytebase_instance.project_databases_instance.name}/databases/test-database",
+	]
+}
+`, base, identifier),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "project", "projects/project-databases"),
+					resource.TestCheckResourceAttr(resourceName, "resolved_databases.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "resolved_databases.*", fmt.Sprintf("instances/%s/databases/default", instanceID)),
+				),
+			},
+			// assign databases by selector
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "bytebase_project_databases" "%s" {
+	project = bytebase_project.project_databases_project.name
+	selector {
+		instances = [bytebase_instance.project_databases_instance.name]
+		query     = "test-database"
+	}
+}
+`, base, identifier),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "resolved_databases.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "resolved_databases.*", fmt.Sprintf("instances/%s/databases/test-database", instanceID)),
+					resource.TestCheckTypeSetElemAttr(resourceName, "resolved_databases.*", fmt.Sprintf("instances/%s/databases/test-database-labels", instanceID)),
+				),
+			},
+		},
+	})
+}
+
+func TestBatchAssignDatabasesReportsPerDatabaseFailures(t *testing.T) {
+	ctx := context.Background()
+	meta, diags := internal.MockProviderConfigure(ctx, schema.TestResourceDataRaw(t, NewProvider().Schema, map[string]interface{}{
+		"url":             "http://bytebase.example.com",
+		"service_account": "service@example.com",
+		"service_key":     "secret",
+	}))
+	if diags.HasError() {
+		t.Fatalf("MockProviderConfigure() returned diagnostics: %v", diags)
+	}
+
+	environment := "environments/test"
+	if _, err := meta.(interface {
+		CreateInstance(context.Context, string, *v1pb.Instance) (*v1pb.Instance, error)
+	}).CreateInstance(ctx, "batch-assign", &v1pb.Instance{
+		Title:       "Test Instance",
+		Engine:      v1pb.Engine_POSTGRES,
+		Environment: &environment,
+	}); err != nil {
+		t.Fatalf("CreateInstance() error = %v", err)
+	}
+
+	assigned, diags := batchAssignDatabases(ctx, meta.(api.Client), []string{
+		"instances/batch-assign/databases/default",
+		"instances/batch-assign/databases/missing",
+		"instances/batch-assign/databases/test-database",
+	}, "projects/batch-assign", 2)
+
+	if got, want := len(assigned), 2; got != want {
+		t.Fatalf("assigned databases = %v, want %d databases", assigned, want)
+	}
+	if got, want := len(diags), 1; got != want {
+		t.Fatalf("diagnostics = %v, want %d diagnostic", diags, want)
+	}
+	if diags[0].Severity != diag.Error {
+		t.Fatalf("diagnostic severity = %v, want error", diags[0].Severity)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"regexp"
 	"testing"
 
 	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
@@ -174,6 +175,104 @@ func TestAccProjectWithSettings(t *testing.T) {
 	})
 }
 
+func TestAccProject_ForcePurgeOnDestroy(t *testing.T) {
+	databaseName := "instances/purge-project-instance/databases/test-database"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: func(*terraform.State) error {
+			c, ok := testAccProvider.Meta().(api.Client)
+			if !ok {
+				return errors.Errorf("cannot get the api client")
+			}
+			if _, err := c.GetProject(context.Background(), "projects/purge-project"); err == nil {
+				return errors.Errorf("project projects/purge-project is not purged")
+			}
+			database, err := c.GetDatabase(context.Background(), databaseName)
+			if err != nil {
+				return err
+			}
+			if database.Project != c.GetDefaultProjectName() {
+				return errors.Errorf("database %s is not moved to the default project, got %s", databaseName, database.Project)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "bytebase_project" "purge_project" {
+	resource_id         = "purge-project"
+	title               = "purge project"
+	databases           = ["${bytebase_instance.purge_project_instance.name}/databases/test-database"]
+	force               = true
+	purge_on_destroy    = true
+	deletion_protection = false
+}
+`, testAccCheckInstanceResource("purge_project_instance", "purge-project-instance", "purge project instance", "POSTGRES", "environments/test")),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists("bytebase_project.purge_project"),
+					resource.TestCheckResourceAttr("bytebase_project.purge_project", "databases.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProject_DeletionProtection(t *testing.T) {
+	resourceName := "bytebase_project.protected_project"
+	config := func(resourceID, deletionProtection string) string {
+		return fmt.Sprintf(`
+resource "bytebase_project" "protected_project" {
+	resource_id = "%s"
+	title       = "protected project"
+	%s
+}
+`, resourceID, deletionProtection)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckProjectDestroy,
+		Steps: []resource.TestStep{
+			// the deletion protection is enabled by default
+			{
+				Config: config("protected-project", ""),
+				Check: resource.ComposeTestCheckFunc(
+					internal.TestCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "true"),
+				),
+			},
+			// cannot destroy the protected project
+			{
+				Config:      config("protected-project", ""),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`cannot destroy the project projects/protected-project because deletion_protection is enabled`),
+			},
+			// cannot destroy the protected project, even if the deletion protection is disabled in the same run
+			{
+				Config:      config("protected-project", "deletion_protection = false"),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`cannot destroy the project projects/protected-project because deletion_protection is enabled`),
+			},
+			// disable the deletion protection so the project can be destroyed
+			{
+				Config: config("protected-project", "deletion_protection = false"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckProjectDestroy(s *terraform.State) error {
 	c, ok := testAccProvider.Meta().(api.Client)
 	if !ok {
@@ -185,7 +284,7 @@ func testAccCheckProjectDestroy(s *terraform.State) error {
 			continue
 		}
 
-		if err := c.DeleteProject(context.Background(), rs.Primary.ID); err != nil {
+		if err := c.DeleteProject(context.Background(), rs.Primary.ID, false /* purge */); err != nil {
 			return err
 		}
 	}
@@ -196,8 +295,9 @@ func testAccCheckProjectDestroy(s *terraform.State) error {
 func testAccCheckProjectResource(identifier, resourceID, title string) string {
 	return fmt.Sprintf(`
 	resource "bytebase_project" "%s" {
-		resource_id    = "%s"
-		title          = "%s"
+		resource_id         = "%s"
+		title               = "%s"
+		deletion_protection = false
 	}
 	`, identifier, resourceID, title)
 }
@@ -237,6 +337,8 @@ func testAccCheckProjectResourceWithSettings(identifier, resourceID, title strin
 			environment = "test"
 			team        = "platform"
 		}
+
+		deletion_protection = false
 	}
 	`, identifier, resourceID, title)
 }
@@ -266,6 +368,8 @@ func testAccCheckProjectResourceWithSettingsUpdated(identifier, resourceID, titl
 		labels = {
 			owner = "dba-team"
 		}
+
+		deletion_protection = false
 	}
 	`, identifier, resourceID, title)
 }
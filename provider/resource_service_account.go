@@ -21,6 +21,7 @@ func resourceServiceAccount() *schema.Resource {
 		DeleteContext: resourceServiceAccountDelete,
 		CreateContext: resourceServiceAccountCreate,
 		UpdateContext: resourceServiceAccountUpdate,
+		CustomizeDiff: validateAdoptExisting("service account", "service_account_id", findExistingServiceAccount),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -66,6 +67,8 @@ func resourceServiceAccount() *schema.Resource {
 				Computed:    true,
 				Description: "The service account state.",
 			},
+			"adopt_existing": getAdoptExistingSchema("service account"),
+			"adoption":       getAdoptionSchema("service account"),
 			"create_time": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -104,12 +107,15 @@ func resourceServiceAccountCreate(ctx context.Context, d *schema.ResourceData, m
 
 	// Check if the service account already exists by listing and matching the ID.
 	existedSA, err := findServiceAccountByID(ctx, c, serviceAccountID)
-	if err != nil {
-		tflog.Debug(ctx, fmt.Sprintf("find service account %s failed with error: %v", serviceAccountID, err))
+	if err != nil && !internal.IsNotFoundError(err) {
+		return diag.Errorf("find service account %s failed with error: %v", serviceAccountID, err)
+	}
+	if diags := checkAdoptExisting(d, "service account", existedSA.GetName(), existedSA != nil && err == nil, existedSA.GetState()); diags.HasError() {
+		return diags
 	}
 
 	var diags diag.Diagnostics
-	if existedSA != nil {
+	if existedSA != nil && err == nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
 			Summary:  "Service account already exists",
@@ -174,6 +180,15 @@ func findServiceAccountByID(ctx context.Context, c api.Client, serviceAccountID
 	return sa, nil
 }
 
+// findExistingServiceAccount returns the name and state of the existing service account by the id.
+func findExistingServiceAccount(ctx context.Context, c api.Client, serviceAccountID string) (string, v1pb.State, error) {
+	sa, err := findServiceAccountByID(ctx, c, serviceAccountID)
+	if err != nil {
+		return "", v1pb.State_STATE_UNSPECIFIED, err
+	}
+	return sa.Name, sa.State, nil
+}
+
 func resourceServiceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(api.Client)
 	name := d.Id()
@@ -22,6 +22,7 @@ func resourceUser() *schema.Resource {
 		DeleteContext: resourceUserDelete,
 		CreateContext: resourceUserCreate,
 		UpdateContext: resourceUserUpdate,
+		CustomizeDiff: validateAdoptExisting("user", "email", findExistingUser),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -70,6 +71,8 @@ func resourceUser() *schema.Resource {
 				Computed:    true,
 				Description: "The user is deleted or not.",
 			},
+			"adopt_existing": getAdoptExistingSchema("user"),
+			"adoption":       getAdoptionSchema("user"),
 			"last_login_time": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -108,6 +111,15 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 	return setUser(d, user)
 }
 
+// findExistingUser returns the name and state of the existing user by the email.
+func findExistingUser(ctx context.Context, c api.Client, email string) (string, v1pb.State, error) {
+	user, err := c.GetUser(ctx, fmt.Sprintf("%s%s", internal.UserNamePrefix, email))
+	if err != nil {
+		return "", v1pb.State_STATE_UNSPECIFIED, err
+	}
+	return user.Name, user.State, nil
+}
+
 func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(api.Client)
 
@@ -115,8 +127,11 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 	userName := fmt.Sprintf("%s%s", internal.UserNamePrefix, email)
 
 	existedUser, err := c.GetUser(ctx, userName)
-	if err != nil {
-		tflog.Debug(ctx, fmt.Sprintf("get user %s failed with error: %v", userName, err))
+	if err != nil && !internal.IsNotFoundError(err) {
+		return diag.Errorf("get user %s failed with error: %v", userName, err)
+	}
+	if diags := checkAdoptExisting(d, "user", userName, existedUser != nil && err == nil, existedUser.GetState()); diags.HasError() {
+		return diags
 	}
 
 	user := &v1pb.User{
@@ -21,6 +21,7 @@ func resourceWorkloadIdentity() *schema.Resource {
 		DeleteContext: resourceWorkloadIdentityDelete,
 		CreateContext: resourceWorkloadIdentityCreate,
 		UpdateContext: resourceWorkloadIdentityUpdate,
+		CustomizeDiff: validateAdoptExisting("workload identity", "workload_identity_id", findExistingWorkloadIdentity),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -66,6 +67,8 @@ func resourceWorkloadIdentity() *schema.Resource {
 				Computed:    true,
 				Description: "The workload identity state.",
 			},
+			"adopt_existing": getAdoptExistingSchema("workload identity"),
+			"adoption":       getAdoptionSchema("workload identity"),
 			"create_time": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -140,12 +143,15 @@ func resourceWorkloadIdentityCreate(ctx context.Context, d *schema.ResourceData,
 
 	// Check if the workload identity already exists by listing and matching the ID.
 	existedWI, err := findWorkloadIdentityByID(ctx, c, workloadIdentityID)
-	if err != nil {
-		tflog.Debug(ctx, fmt.Sprintf("find workload identity %s failed with error: %v", workloadIdentityID, err))
+	if err != nil && !internal.IsNotFoundError(err) {
+		return diag.Errorf("find workload identity %s failed with error: %v", workloadIdentityID, err)
+	}
+	if diags := checkAdoptExisting(d, "workload identity", existedWI.GetName(), existedWI != nil && err == nil, existedWI.GetState()); diags.HasError() {
+		return diags
 	}
 
 	var diags diag.Diagnostics
-	if existedWI != nil {
+	if existedWI != nil && err == nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
 			Summary:  "Workload identity already exists",
@@ -222,6 +228,15 @@ func findWorkloadIdentityByID(ctx context.Context, c api.Client, workloadIdentit
 	return wi, nil
 }
 
+// findExistingWorkloadIdentity returns the name and state of the existing workload identity by the id.
+func findExistingWorkloadIdentity(ctx context.Context, c api.Client, workloadIdentityID string) (string, v1pb.State, error) {
+	wi, err := findWorkloadIdentityByID(ctx, c, workloadIdentityID)
+	if err != nil {
+		return "", v1pb.State_STATE_UNSPECIFIED, err
+	}
+	return wi.Name, wi.State, nil
+}
+
 func resourceWorkloadIdentityUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(api.Client)
 	name := d.Id()
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/pkg/errors"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+)
+
+// errRolloutPending is returned if the rollout is not finished before the timeout, for example waiting for the approval.
+var errRolloutPending = errors.New("the rollout is not finished")
+
+// waitForIssueRollout polls the issue rollout until all tasks are done or skipped.
+// It returns the error if any task fails or is canceled, and the error wrapping errRolloutPending if the tasks are still pending at the timeout.
+func waitForIssueRollout(ctx context.Context, client api.Client, issue *v1pb.Issue, timeout time.Duration) error {
+	if issue.GetRollout() == "" {
+		return errors.Errorf("no rollout found in the issue %s", issue.GetName())
+	}
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		rollout, err := client.GetRollout(ctx, issue.Rollout)
+		if err != nil {
+			return retry.RetryableError(err)
+		}
+		for _, stage := range rollout.Stages {
+			for _, task := range stage.Tasks {
+				switch task.Status {
+				case v1pb.Task_DONE, v1pb.Task_SKIPPED:
+					continue
+				case v1pb.Task_FAILED, v1pb.Task_CANCELED:
+					return retry.NonRetryableError(errors.Errorf("task %s in the issue %s is %s", task.Name, issue.Name, task.Status.String()))
+				default:
+					return retry.RetryableError(errors.Wrapf(errRolloutPending, "task %s in the issue %s is %s", task.Name, issue.Name, task.Status.String()))
+				}
+			}
+		}
+		tflog.Debug(ctx, fmt.Sprintf("the rollout %s for the issue %s is finished", rollout.Name, issue.Name))
+		return nil
+	})
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	v1pb "buf.build/gen/go/bytebase/bytebase/protocolbuffers/go/v1"
+
+	"github.com/bytebase/terraform-provider-bytebase/api"
+)
+
+// rolloutStatusClient returns the rollout with a single task in the status.
+type rolloutStatusClient struct {
+	api.Client
+	status v1pb.Task_Status
+}
+
+func (c rolloutStatusClient) GetRollout(_ context.Context, rolloutName string) (*v1pb.Rollout, error) {
+	return &v1pb.Rollout{
+		Name: rolloutName,
+		Stages: []*v1pb.Stage{
+			{
+				Name: rolloutName + "/stages/1",
+				Tasks: []*v1pb.Task{
+					{Name: rolloutName + "/stages/1/tasks/1", Status: c.status},
+				},
+			},
+		},
+	}, nil
+}
+
+func TestWaitForIssueRollout(t *testing.T) {
+	issue := &v1pb.Issue{
+		Name:    "projects/p/issues/1",
+		Rollout: "projects/p/rollouts/1",
+	}
+	tests := []struct {
+		status  v1pb.Task_Status
+		pending bool
+		wantErr string
+	}{
+		{status: v1pb.Task_DONE},
+		{status: v1pb.Task_SKIPPED},
+		{status: v1pb.Task_FAILED, wantErr: "FAILED"},
+		{status: v1pb.Task_CANCELED, wantErr: "CANCELED"},
+		{status: v1pb.Task_PENDING, pending: true, wantErr: "PENDING"},
+	}
+	for _, test := range tests {
+		t.Run(test.status.String(), func(t *testing.T) {
+			err := waitForIssueRollout(context.Background(), rolloutStatusClient{status: test.status}, issue, time.Second)
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("waitForIssueRollout() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("waitForIssueRollout() error = %v, want %s", err, test.wantErr)
+			}
+			if got := errors.Is(err, errRolloutPending); got != test.pending {
+				t.Fatalf("errors.Is(err, errRolloutPending) = %v, want %v", got, test.pending)
+			}
+		})
+	}
+
+	if err := waitForIssueRollout(context.Background(), rolloutStatusClient{}, &v1pb.Issue{Name: "projects/p/issues/2"}, time.Second); err == nil {
+		t.Fatalf("waitForIssueRollout() returned no error for the issue without rollout")
+	}
+}